@@ -0,0 +1,55 @@
+package mcts
+
+import "snakegame/engine"
+
+// FNV-1a offset/prime constants for the 64-bit variant.
+const (
+	fnvOffset64 uint64 = 14695981039346656037
+	fnvPrime64  uint64 = 1099511628211
+)
+
+// hashState produces a fast 64-bit digest of the parts of gs that matter for
+// search: the turn counter, both snake bodies (and their timers), and the
+// current apples. It is used as the transposition map key so that two
+// distinct playout paths which happen to reach the same board get their
+// statistics merged instead of explored from scratch.
+func hashState(gs *engine.GameState) uint64 {
+	h := fnvOffset64
+
+	mix := func(n int) {
+		h ^= uint64(int64(n))
+		h *= fnvPrime64
+	}
+
+	mix(gs.Turn)
+
+	for _, snake := range gs.Snakes {
+		mix(snake.ID)
+		mix(len(snake.Body))
+		for _, pos := range snake.Body {
+			mix(pos.X)
+			mix(pos.Y)
+		}
+		mix(btoi(snake.Alive))
+		mix(snake.SpeedTurns)
+		mix(snake.SleepTurns)
+		mix(snake.Energy)
+	}
+
+	for _, apple := range gs.Apples {
+		mix(apple.X)
+		mix(apple.Y)
+		for _, r := range apple.Type {
+			mix(int(r))
+		}
+	}
+
+	return h
+}
+
+func btoi(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,140 @@
+package mcts
+
+import "snakegame/engine"
+
+// appleWeight biases the heuristic toward valuable apples and away from
+// poison, mirroring ApplyAppleEffect's actual payoff.
+func appleWeight(t engine.AppleType) float64 {
+	switch t {
+	case engine.AppleGod:
+		return 3.0
+	case engine.AppleSpeed:
+		return 1.5
+	case engine.AppleSleep:
+		return 1.2
+	case engine.ApplePoison:
+		return -1.5
+	default:
+		return 1.0
+	}
+}
+
+// floodFill returns the number of empty cells reachable from start via
+// 4-directional movement, stopping early at maxCells. It approximates how
+// much room a snake has to maneuver, which is a much better survival signal
+// than raw length once two snakes start boxing each other in.
+func floodFill(gs *engine.GameState, start engine.Position, maxCells int) int {
+	if start.X < 0 || start.X >= gs.GridWidth || start.Y < 0 || start.Y >= gs.GridHeight {
+		return 0
+	}
+
+	blocked := make(map[engine.Position]bool, gs.GridWidth*gs.GridHeight/4)
+	for _, snake := range gs.Snakes {
+		for _, seg := range snake.Body {
+			blocked[seg] = true
+		}
+	}
+	if gs.Map != nil {
+		for _, obs := range gs.Map.Obstacles {
+			blocked[obs] = true
+		}
+	}
+
+	visited := make(map[engine.Position]bool, maxCells)
+	queue := []engine.Position{start}
+	visited[start] = true
+	count := 0
+
+	for len(queue) > 0 && count < maxCells {
+		pos := queue[0]
+		queue = queue[1:]
+		count++
+
+		neighbors := [4]engine.Position{
+			{X: pos.X, Y: pos.Y - 1},
+			{X: pos.X, Y: pos.Y + 1},
+			{X: pos.X - 1, Y: pos.Y},
+			{X: pos.X + 1, Y: pos.Y},
+		}
+		for _, n := range neighbors {
+			if n.X < 0 || n.X >= gs.GridWidth || n.Y < 0 || n.Y >= gs.GridHeight {
+				continue
+			}
+			if visited[n] || blocked[n] {
+				continue
+			}
+			visited[n] = true
+			queue = append(queue, n)
+		}
+	}
+
+	return count
+}
+
+// nearestAppleScore returns a score between 0 and the largest appleWeight
+// that grows as the closest apple gets nearer, weighted by how valuable that
+// apple is. It is 0 when there are no apples, or when every apple scores
+// below zero (only poison on the board).
+func nearestAppleScore(gs *engine.GameState, head engine.Position) float64 {
+	best := -1.0
+	for _, apple := range gs.Apples {
+		dist := manhattanDistance(head, engine.Position{X: apple.X, Y: apple.Y})
+		score := appleWeight(apple.Type) / float64(1+dist)
+		if score > best {
+			best = score
+		}
+	}
+	if best < 0 {
+		return 0
+	}
+	return best
+}
+
+func manhattanDistance(a, b engine.Position) int {
+	dx := a.X - b.X
+	if dx < 0 {
+		dx = -dx
+	}
+	dy := a.Y - b.Y
+	if dy < 0 {
+		dy = -dy
+	}
+	return dx + dy
+}
+
+// evaluate scores the terminal (or turn-limited) state from snakeID's point
+// of view, combining length, score, energy, survival, reachable space and
+// apple proximity into a single playout reward in roughly [-1, 1].
+func evaluate(gs *engine.GameState, snakeID int) float64 {
+	self := gs.Snakes[snakeID-1]
+	other := gs.Snakes[2-snakeID]
+
+	if !self.Alive && !other.Alive {
+		return 0
+	}
+	if !self.Alive {
+		return -1
+	}
+	if !other.Alive {
+		return 1
+	}
+
+	const maxFloodCells = 80
+	selfSpace := float64(floodFill(gs, self.GetHead(), maxFloodCells))
+	otherSpace := float64(floodFill(gs, other.GetHead(), maxFloodCells))
+
+	score := 0.0
+	score += 0.35 * float64(self.Length-other.Length)
+	score += 0.20 * float64(self.Score-other.Score)
+	score += 0.10 * float64(self.Energy-other.Energy) / 60.0
+	score += 0.20 * (selfSpace - otherSpace) / maxFloodCells
+	score += 0.15 * (nearestAppleScore(gs, self.GetHead()) - nearestAppleScore(gs, other.GetHead()))
+
+	// Squash into [-1, 1] so it composes cleanly with the win/loss cases above.
+	if score > 1 {
+		score = 1
+	} else if score < -1 {
+		score = -1
+	}
+	return score
+}
@@ -0,0 +1,267 @@
+// Package mcts implements a UCT/MCTS reference bot for the snake engine.
+// Each turn is treated as a simultaneous-move node: both snakes pick a move
+// at the same time, so selection uses decoupled UCB1 (each player optimizes
+// its own marginal statistics over the joint-action edges) rather than the
+// single-agent version.
+package mcts
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"snakegame/engine"
+)
+
+var allDirections = [4]engine.Direction{
+	engine.DirectionUp,
+	engine.DirectionDown,
+	engine.DirectionLeft,
+	engine.DirectionRight,
+}
+
+const explorationConstant = 1.41421356 // sqrt(2)
+
+// Config controls the search budget. Either limit can cut a search short;
+// whichever is reached first wins.
+type Config struct {
+	TimeBudget      time.Duration
+	MaxIterations   int
+	MaxPlayoutTurns int
+}
+
+// DefaultConfig matches the budget described for the reference bot: a
+// 150ms time slice per move, generous enough to explore thousands of
+// playouts on a typical board.
+func DefaultConfig() Config {
+	return Config{
+		TimeBudget:      150 * time.Millisecond,
+		MaxIterations:   20000,
+		MaxPlayoutTurns: 80,
+	}
+}
+
+// Bot is a UCT search bot over the two-player simultaneous-move snake game.
+type Bot struct {
+	Config Config
+	rng    *rand.Rand
+}
+
+// NewBot creates a bot with the default search budget.
+func NewBot() *Bot {
+	return NewBotWithSeed(time.Now().UnixNano())
+}
+
+// NewBotWithSeed creates a bot whose internal playout randomness is
+// reproducible from seed, which is useful for regression tests once the
+// engine's own RNG is deterministic per GameState.
+func NewBotWithSeed(seed int64) *Bot {
+	return &Bot{
+		Config: DefaultConfig(),
+		rng:    rand.New(rand.NewSource(seed)),
+	}
+}
+
+// edge holds the accumulated decoupled statistics and resulting child for
+// one of the (up to) 16 joint move pairs available at a node.
+type edge struct {
+	move1, move2 engine.Direction
+	child        *node
+	visits       int
+	value1       float64
+	value2       float64
+}
+
+// node is one position in the search tree, keyed in the transposition map
+// by (Turn, board hash) so that transpositions share statistics.
+type node struct {
+	state *engine.GameState
+	edges map[[2]engine.Direction]*edge
+
+	visits  int
+	visits1 [4]int
+	value1  [4]float64
+	visits2 [4]int
+	value2  [4]float64
+}
+
+func newNode(state *engine.GameState) *node {
+	return &node{
+		state: state,
+		edges: make(map[[2]engine.Direction]*edge),
+	}
+}
+
+// legalMoves returns the directions snake may take this turn, excluding the
+// reversal of its current heading (mirrors Snake's own isNot180 rule,
+// which is unexported).
+func legalMoves(snake *engine.Snake) []engine.Direction {
+	if len(snake.Body) < 2 {
+		return allDirections[:]
+	}
+
+	opposite := map[engine.Direction]engine.Direction{
+		engine.DirectionUp:    engine.DirectionDown,
+		engine.DirectionDown:  engine.DirectionUp,
+		engine.DirectionLeft:  engine.DirectionRight,
+		engine.DirectionRight: engine.DirectionLeft,
+	}
+	reverse := opposite[snake.Direction]
+
+	moves := make([]engine.Direction, 0, 4)
+	for _, d := range allDirections {
+		if d != reverse {
+			moves = append(moves, d)
+		}
+	}
+	return moves
+}
+
+// SelectMove runs UCT search from gs and returns the best move found for
+// botID (1 or 2) within the configured time/iteration budget.
+func (b *Bot) SelectMove(gs *engine.GameState, botID int) engine.Direction {
+	if !gs.Snakes[botID-1].Alive {
+		return gs.Snakes[botID-1].Direction
+	}
+
+	root := newNode(gs.Clone())
+	transposition := map[uint64]*node{hashState(root.state): root}
+
+	deadline := time.Now().Add(b.Config.TimeBudget)
+	iterations := 0
+	for iterations < b.Config.MaxIterations && time.Now().Before(deadline) {
+		b.simulate(root, transposition, 0)
+		iterations++
+	}
+
+	visits, value := &root.visits1, &root.value1
+	if botID == 2 {
+		visits, value = &root.visits2, &root.value2
+	}
+
+	best := 0
+	bestScore := math.Inf(-1)
+	for i, v := range visits {
+		if v == 0 {
+			continue
+		}
+		avg := value[i] / float64(v)
+		if avg > bestScore {
+			bestScore = avg
+			best = i
+		}
+	}
+	return allDirections[best]
+}
+
+// simulate performs one selection/expansion/playout/backprop pass starting
+// at n and returns the (player1, player2) reward obtained.
+func (b *Bot) simulate(n *node, transposition map[uint64]*node, depth int) (float64, float64) {
+	if n.state.GameOver || depth >= b.Config.MaxPlayoutTurns {
+		return evaluate(n.state, 1), evaluate(n.state, 2)
+	}
+
+	move1, move2 := b.selectJoint(n)
+	key := [2]engine.Direction{move1, move2}
+
+	e, ok := n.edges[key]
+	if !ok {
+		child := engine.Simulate(n.state, move1, move2)
+		childHash := hashState(child)
+		childNode, seen := transposition[childHash]
+		if !seen {
+			childNode = newNode(child)
+			transposition[childHash] = childNode
+		}
+		e = &edge{move1: move1, move2: move2, child: childNode}
+		n.edges[key] = e
+	}
+
+	var reward1, reward2 float64
+	if e.child.visits == 0 {
+		reward1, reward2 = b.rollout(e.child.state, depth+1)
+		e.child.visits = 1 // expanded: future visits descend instead of re-rolling out
+	} else {
+		reward1, reward2 = b.simulate(e.child, transposition, depth+1)
+	}
+
+	b.backprop(n, e, move1, move2, reward1, reward2)
+	return reward1, reward2
+}
+
+// selectJoint picks each player's move independently via UCB1 over that
+// player's marginal visit/value statistics, which are accumulated on the
+// joint-action edges as moves are explored.
+func (b *Bot) selectJoint(n *node) (engine.Direction, engine.Direction) {
+	move1 := b.selectForPlayer(n.state.Snakes[0], n.visits, n.visits1[:], n.value1[:])
+	move2 := b.selectForPlayer(n.state.Snakes[1], n.visits, n.visits2[:], n.value2[:])
+	return move1, move2
+}
+
+func (b *Bot) selectForPlayer(snake *engine.Snake, parentVisits int, visits []int, value []float64) engine.Direction {
+	candidates := legalMoves(snake)
+
+	// Always try an unvisited move first so every legal action gets a
+	// baseline estimate before UCB1 starts trading off exploration.
+	for _, d := range candidates {
+		if visits[indexOf(d)] == 0 {
+			return d
+		}
+	}
+
+	best := candidates[0]
+	bestScore := math.Inf(-1)
+	for _, d := range candidates {
+		i := indexOf(d)
+		exploit := value[i] / float64(visits[i])
+		explore := explorationConstant * math.Sqrt(math.Log(float64(parentVisits+1))/float64(visits[i]))
+		score := exploit + explore
+		if score > bestScore {
+			bestScore = score
+			best = d
+		}
+	}
+	return best
+}
+
+func indexOf(d engine.Direction) int {
+	for i, candidate := range allDirections {
+		if candidate == d {
+			return i
+		}
+	}
+	return 0
+}
+
+// rollout expands one new node via a random playout to the turn limit,
+// scoring the resulting (possibly non-terminal) state with the heuristic.
+func (b *Bot) rollout(state *engine.GameState, depth int) (float64, float64) {
+	current := state
+	for turns := depth; turns < b.Config.MaxPlayoutTurns && !current.GameOver; turns++ {
+		move1 := b.randomMove(current.Snakes[0])
+		move2 := b.randomMove(current.Snakes[1])
+		current = engine.Simulate(current, move1, move2)
+	}
+	return evaluate(current, 1), evaluate(current, 2)
+}
+
+func (b *Bot) randomMove(snake *engine.Snake) engine.Direction {
+	if !snake.Alive {
+		return snake.Direction
+	}
+	candidates := legalMoves(snake)
+	return candidates[b.rng.Intn(len(candidates))]
+}
+
+func (b *Bot) backprop(n *node, e *edge, move1, move2 engine.Direction, reward1, reward2 float64) {
+	n.visits++
+	e.visits++
+	e.value1 += reward1
+	e.value2 += reward2
+
+	i1, i2 := indexOf(move1), indexOf(move2)
+	n.visits1[i1]++
+	n.value1[i1] += reward1
+	n.visits2[i2]++
+	n.value2[i2] += reward2
+}
@@ -0,0 +1,106 @@
+package mcts
+
+import (
+	"testing"
+	"time"
+
+	"snakegame/engine"
+)
+
+// testConfig trims the default search budget down to something that runs
+// quickly under `go test` while still doing enough iterations to exercise
+// selection, expansion, rollout, and backprop.
+func testConfig() Config {
+	return Config{
+		TimeBudget:      50 * time.Millisecond,
+		MaxIterations:   500,
+		MaxPlayoutTurns: 40,
+	}
+}
+
+func TestSelectMoveReturnsLegalMove(t *testing.T) {
+	gs := engine.NewGameStateWithSeed(11, 11, nil, 1)
+	bot := NewBotWithSeed(1)
+	bot.Config = testConfig()
+
+	move := bot.SelectMove(gs, 1)
+
+	legal := legalMoves(gs.Snakes[0])
+	found := false
+	for _, d := range legal {
+		if d == move {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Errorf("SelectMove returned %q, not among legal moves %v", move, legal)
+	}
+}
+
+func TestSelectMoveIsDeterministicForASeed(t *testing.T) {
+	gs1 := engine.NewGameStateWithSeed(11, 11, nil, 42)
+	gs2 := engine.NewGameStateWithSeed(11, 11, nil, 42)
+
+	// A generous time budget so both searches stop at the iteration cap,
+	// not at a wall-clock deadline that could cut them off at different
+	// iteration counts.
+	bot1 := NewBotWithSeed(7)
+	bot1.Config = testConfig()
+	bot1.Config.TimeBudget = time.Minute
+	bot2 := NewBotWithSeed(7)
+	bot2.Config = testConfig()
+	bot2.Config.TimeBudget = time.Minute
+
+	move1 := bot1.SelectMove(gs1, 1)
+	move2 := bot2.SelectMove(gs2, 1)
+
+	if move1 != move2 {
+		t.Errorf("same seed produced different moves: %q vs %q", move1, move2)
+	}
+}
+
+func TestSelectMoveOnDeadSnakeHoldsDirection(t *testing.T) {
+	gs := engine.NewGameStateWithSeed(11, 11, nil, 1)
+	gs.Snakes[0].Alive = false
+	gs.Snakes[0].Direction = engine.DirectionLeft
+
+	bot := NewBotWithSeed(1)
+	bot.Config = testConfig()
+
+	move := bot.SelectMove(gs, 1)
+	if move != engine.DirectionLeft {
+		t.Errorf("SelectMove on a dead snake = %q, want its held direction %q", move, engine.DirectionLeft)
+	}
+}
+
+func TestSelectMoveAvoidsCertainDeathIntoWall(t *testing.T) {
+	// Snake 1 sits one cell from the left wall, facing left, with nothing
+	// but open board elsewhere - moving left is instant death, so a search
+	// with any meaningful budget should never pick it.
+	gs := engine.NewGameStateForBots(11, 11, nil, 1, 2)
+	gs.Snakes[0] = &engine.Snake{
+		ID:        1,
+		Body:      []engine.Position{{X: 0, Y: 5}, {X: 1, Y: 5}},
+		Direction: engine.DirectionLeft,
+		Alive:     true,
+		Length:    2,
+		Energy:    60,
+	}
+	gs.Snakes[1] = &engine.Snake{
+		ID:        2,
+		Body:      []engine.Position{{X: 9, Y: 9}, {X: 9, Y: 8}},
+		Direction: engine.DirectionDown,
+		Alive:     true,
+		Length:    2,
+		Energy:    60,
+	}
+
+	bot := NewBotWithSeed(3)
+	bot.Config = testConfig()
+
+	move := bot.SelectMove(gs, 1)
+	if move == engine.DirectionLeft {
+		t.Errorf("SelectMove chose %q, walking snake 1 straight into the wall", move)
+	}
+}
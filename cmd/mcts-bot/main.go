@@ -0,0 +1,40 @@
+// Command mcts-bot is a reference Battlesnake-style bot driven by the
+// ai/mcts search. It speaks the engine's legacy stdio protocol: one
+// GameState JSON object per line on stdin, one {"move": "..."} JSON object
+// per line on stdout.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"snakegame/ai/mcts"
+	"snakegame/engine"
+)
+
+func main() {
+	bot := mcts.NewBot()
+	scanner := bufio.NewScanner(os.Stdin)
+	// Game states can be large on bigger boards; grow the buffer past the
+	// default 64KiB line limit.
+	scanner.Buffer(make([]byte, 0, 1<<20), 1<<20)
+	writer := json.NewEncoder(os.Stdout)
+
+	for scanner.Scan() {
+		var state engine.GameState
+		if err := json.Unmarshal(scanner.Bytes(), &state); err != nil {
+			fmt.Fprintf(os.Stderr, "mcts-bot: failed to decode game state: %v\n", err)
+			continue
+		}
+
+		// The engine always reorders the receiving bot's snake to index 0
+		// before sending state, so from here we are always "player 1".
+		move := bot.SelectMove(&state, 1)
+
+		if err := writer.Encode(map[string]string{"move": string(move)}); err != nil {
+			fmt.Fprintf(os.Stderr, "mcts-bot: failed to encode move: %v\n", err)
+		}
+	}
+}
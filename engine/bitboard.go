@@ -0,0 +1,72 @@
+package engine
+
+import "snakegame/engine/bitboard"
+
+// ToBitboard encodes gs into the compact bitboard representation, or returns
+// nil if the board exceeds bitboard.MaxDimension on either axis. Callers
+// should check for nil and fall back to GameState's ordinary scanning
+// methods.
+func (gs *GameState) ToBitboard() *bitboard.Board {
+	if !bitboard.Fits(gs.GridWidth, gs.GridHeight) {
+		return nil
+	}
+
+	bb := bitboard.NewBoard(gs.GridWidth, gs.GridHeight, len(gs.Snakes))
+
+	for i, snake := range gs.Snakes {
+		cells := make([]int, 0, len(snake.Body))
+		for _, pos := range snake.Body {
+			// A snake whose head has just moved off the grid is about to be
+			// (or already was) killed by the wall check; skip the
+			// out-of-bounds segment rather than handing SetBody an index
+			// past the board's cell count.
+			if pos.X < 0 || pos.X >= gs.GridWidth || pos.Y < 0 || pos.Y >= gs.GridHeight {
+				continue
+			}
+			cells = append(cells, bb.Index(pos.X, pos.Y))
+		}
+		bb.SetBody(i, cells)
+	}
+
+	if gs.Map != nil {
+		for _, obs := range gs.Map.Obstacles {
+			bb.SetObstacle(bb.Index(obs.X, obs.Y))
+		}
+	}
+
+	for _, apple := range gs.Apples {
+		bb.SetApple(bb.Index(apple.X, apple.Y), string(apple.Type))
+	}
+
+	return bb
+}
+
+// FromBitboard syncs gs's apples and map obstacles from bb. Snake bodies are
+// not round-tripped: GameState's Snake.Body already carries richer ordering
+// and per-segment information than a bitboard can represent, so it remains
+// the source of truth for movement; the bitboard is purely a derived fast
+// path for queries.
+func (gs *GameState) FromBitboard(bb *bitboard.Board) {
+	apples := make([]Apple, 0, len(gs.Apples))
+	for t, mask := range bb.Apples {
+		for i := 0; i < bb.Width*bb.Height; i++ {
+			if mask.Test(i) {
+				x, y := bb.Coords(i)
+				apples = append(apples, Apple{X: x, Y: y, Type: AppleType(t)})
+			}
+		}
+	}
+	gs.Apples = apples
+
+	if gs.Map == nil {
+		return
+	}
+	obstacles := make([]Position, 0, len(gs.Map.Obstacles))
+	for i := 0; i < bb.Width*bb.Height; i++ {
+		if bb.IsObstacle(i) {
+			x, y := bb.Coords(i)
+			obstacles = append(obstacles, Position{X: x, Y: y})
+		}
+	}
+	gs.Map.Obstacles = obstacles
+}
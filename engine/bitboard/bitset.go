@@ -0,0 +1,49 @@
+package bitboard
+
+// Bitset is a fixed-size set of cell indices packed into 64-bit words, used
+// as the building block for every mask on a Board.
+type Bitset []uint64
+
+// NewBitset allocates a Bitset large enough to hold indices [0, bits).
+func NewBitset(bits int) Bitset {
+	return make(Bitset, (bits+63)/64)
+}
+
+// Set marks i as present.
+func (b Bitset) Set(i int) {
+	b[i/64] |= 1 << uint(i%64)
+}
+
+// Clear marks i as absent.
+func (b Bitset) Clear(i int) {
+	b[i/64] &^= 1 << uint(i%64)
+}
+
+// Test reports whether i is present.
+func (b Bitset) Test(i int) bool {
+	return b[i/64]&(1<<uint(i%64)) != 0
+}
+
+// Clone returns an independent copy of b.
+func (b Bitset) Clone() Bitset {
+	out := make(Bitset, len(b))
+	copy(out, b)
+	return out
+}
+
+// Or sets every bit present in other onto b (both must be the same length).
+func (b Bitset) Or(other Bitset) {
+	for i := range b {
+		b[i] |= other[i]
+	}
+}
+
+// Empty reports whether no bit is set.
+func (b Bitset) Empty() bool {
+	for _, word := range b {
+		if word != 0 {
+			return false
+		}
+	}
+	return true
+}
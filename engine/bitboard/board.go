@@ -0,0 +1,150 @@
+// Package bitboard provides a compact occupancy encoding for boards up to
+// MaxDimension on a side, giving O(1) move/collision/apple queries instead
+// of the linear scans GameState otherwise performs. It intentionally knows
+// nothing about the engine package's types (to avoid an import cycle with
+// engine, which builds a Board from a GameState) - positions are plain cell
+// indices and apple types are plain strings mirroring engine.AppleType's
+// values.
+package bitboard
+
+// MaxDimension is the largest board width/height this package supports (the
+// Battlesnake standard). Boards bigger than this should fall back to the
+// engine's ordinary scanning code paths.
+const MaxDimension = 19
+
+// Board is a compact occupancy encoding of an N-snake board.
+type Board struct {
+	Width, Height int
+
+	// Bodies holds one occupancy mask per snake, indexed the same way as
+	// GameState.Snakes, including the head cell.
+	Bodies []Bitset
+	// Trailing holds the same masks with each head cell excluded - the mask
+	// to test an incoming head position against for self/body collisions,
+	// since a snake's own head trivially "occupies" its own cell.
+	Trailing []Bitset
+	// Heads holds each snake's head cell index, or -1 if it has no body.
+	Heads []int
+
+	Obstacles Bitset
+
+	// Apples maps an apple type string (e.g. "NORMAL", "GOD") to the mask
+	// of cells holding an apple of that type.
+	Apples map[string]Bitset
+}
+
+// NewBoard allocates an empty Board for the given dimensions and number of
+// snakes. Callers should check Fits(width, height) before using this
+// instead of GameState's default scanning code paths.
+func NewBoard(width, height, numSnakes int) *Board {
+	cells := width * height
+	bb := &Board{
+		Width:     width,
+		Height:    height,
+		Bodies:    make([]Bitset, numSnakes),
+		Trailing:  make([]Bitset, numSnakes),
+		Heads:     make([]int, numSnakes),
+		Obstacles: NewBitset(cells),
+		Apples:    make(map[string]Bitset),
+	}
+	for i := 0; i < numSnakes; i++ {
+		bb.Bodies[i] = NewBitset(cells)
+		bb.Trailing[i] = NewBitset(cells)
+		bb.Heads[i] = -1
+	}
+	return bb
+}
+
+// Fits reports whether a board of the given dimensions is small enough to
+// use the bitboard fast path.
+func Fits(width, height int) bool {
+	return width > 0 && height > 0 && width <= MaxDimension && height <= MaxDimension
+}
+
+// Index converts (x, y) into a flat cell index.
+func (b *Board) Index(x, y int) int {
+	return y*b.Width + x
+}
+
+// Coords converts a flat cell index back into (x, y).
+func (b *Board) Coords(i int) (x, y int) {
+	return i % b.Width, i / b.Width
+}
+
+// SetBody (re)populates the occupancy mask for snake (its index in Bodies) from a list
+// of cell indices, head first.
+func (b *Board) SetBody(snake int, cells []int) {
+	b.Bodies[snake] = NewBitset(b.Width * b.Height)
+	b.Trailing[snake] = NewBitset(b.Width * b.Height)
+	b.Heads[snake] = -1
+	for i, cell := range cells {
+		b.Bodies[snake].Set(cell)
+		if i == 0 {
+			b.Heads[snake] = cell
+		} else {
+			b.Trailing[snake].Set(cell)
+		}
+	}
+}
+
+// SetObstacle marks i as a static obstacle.
+func (b *Board) SetObstacle(i int) {
+	b.Obstacles.Set(i)
+}
+
+// SetApple marks i as holding an apple of the given type, clearing any
+// other apple type previously recorded there.
+func (b *Board) SetApple(i int, appleType string) {
+	for t, mask := range b.Apples {
+		if t != appleType {
+			mask.Clear(i)
+		}
+	}
+	mask, ok := b.Apples[appleType]
+	if !ok {
+		mask = NewBitset(b.Width * b.Height)
+		b.Apples[appleType] = mask
+	}
+	mask.Set(i)
+}
+
+// ClearApple removes any apple recorded at i.
+func (b *Board) ClearApple(i int) {
+	for _, mask := range b.Apples {
+		mask.Clear(i)
+	}
+}
+
+// IsSnakeBody reports whether cell i is occupied by the given snake index.
+func (b *Board) IsSnakeBody(snake, i int) bool {
+	return b.Bodies[snake].Test(i)
+}
+
+// IsObstacle reports whether cell i is a static obstacle.
+func (b *Board) IsObstacle(i int) bool {
+	return b.Obstacles.Test(i)
+}
+
+// Occupied reports whether cell i is blocked by any snake or an obstacle -
+// the common "can something move here" query.
+func (b *Board) Occupied(i int) bool {
+	if b.Obstacles.Test(i) {
+		return true
+	}
+	for _, body := range b.Bodies {
+		if body.Test(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// AppleAt returns the apple type at cell i, if any.
+func (b *Board) AppleAt(i int) (string, bool) {
+	for t, mask := range b.Apples {
+		if mask.Test(i) {
+			return t, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,94 @@
+package engine
+
+import (
+	"sync"
+	"time"
+)
+
+// logLineBufferSize bounds each subscriber's backlog. A subscriber that
+// falls behind loses its oldest buffered line rather than blocking the
+// broadcaster, so a slow spectator can't stall the game loop.
+const logLineBufferSize = 256
+
+// LogLine is one line of a bot's stderr, broadcast to every Subscribe-r
+// alongside the rotating per-bot log file.
+type LogLine struct {
+	BotID     int       `json:"bot_id"`
+	Stream    string    `json:"stream"`
+	Line      string    `json:"line"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// stderrBroadcaster fans out stderr lines to every current subscriber,
+// the same way Docker's attach stream multiplexes stdout/stderr to a
+// single reader - except here there can be any number of readers, added
+// and removed at runtime by spectators connecting and disconnecting.
+type stderrBroadcaster struct {
+	mu          sync.Mutex
+	subscribers map[chan LogLine]struct{}
+	dropped     int
+}
+
+func newStderrBroadcaster() *stderrBroadcaster {
+	return &stderrBroadcaster{subscribers: make(map[chan LogLine]struct{})}
+}
+
+// subscribe registers ch to receive every future publish until
+// unsubscribe(ch) is called.
+func (b *stderrBroadcaster) subscribe(ch chan LogLine) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers[ch] = struct{}{}
+}
+
+// unsubscribe removes and closes ch. Safe to call more than once.
+func (b *stderrBroadcaster) unsubscribe(ch chan LogLine) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.subscribers[ch]; ok {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+}
+
+// publish fans line out to every current subscriber. A subscriber whose
+// channel is already full has its oldest buffered line dropped (and
+// counted) to make room, rather than blocking the caller.
+func (b *stderrBroadcaster) publish(line LogLine) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subscribers {
+		select {
+		case ch <- line:
+			continue
+		default:
+		}
+
+		select {
+		case <-ch:
+			b.dropped++
+		default:
+		}
+		select {
+		case ch <- line:
+		default:
+		}
+	}
+}
+
+// droppedCount returns how many buffered lines have been dropped across
+// all subscribers so far, for surfacing via GetStats.
+func (b *stderrBroadcaster) droppedCount() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.dropped
+}
+
+// Subscribe returns a channel of this bot's stderr lines as they're
+// logged, and a closer to unsubscribe. Used by the HTTP layer to stream a
+// running bot's stderr to spectators over a websocket.
+func (bp *BotPlayer) Subscribe() (<-chan LogLine, func()) {
+	ch := make(chan LogLine, logLineBufferSize)
+	bp.broadcaster.subscribe(ch)
+	return ch, func() { bp.broadcaster.unsubscribe(ch) }
+}
@@ -0,0 +1,291 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// calibrationTimeout bounds how long a single calibrator run (including
+// interpreter startup) may take before it's treated as unavailable, so a
+// missing Python/Node install doesn't add multi-second latency to every
+// match.
+const calibrationTimeout = 3 * time.Second
+
+// referenceCalibratePath stores one measured baseline per backend, so the
+// scale factor for a match reflects the language its bots are actually
+// written in rather than always being relative to Python.
+const referenceCalibratePath = "tools/reference_calibrate.json"
+
+// calibrateResult is what a Calibrator measures: how long its backend's
+// microbenchmark took on this host.
+type calibrateResult struct {
+	Seconds float64 `json:"seconds"`
+	Backend string  `json:"backend"`
+}
+
+// Calibrator measures how fast this host runs a particular language or
+// runtime, so a match's turn timeouts can be scaled relative to a
+// reference baseline for that same backend instead of an arbitrary one -
+// a bot written in Go shouldn't have its timeout budget scaled by how fast
+// this host happens to run Python.
+type Calibrator interface {
+	// Backend names this calibrator, used to key its baseline in
+	// referenceCalibratePath and to select it via
+	// MatchConfig.CalibrationBackend.
+	Backend() string
+	// Calibrate runs the backend's microbenchmark and reports how long it
+	// took. It should respect ctx's deadline.
+	Calibrate(ctx context.Context) (calibrateResult, error)
+}
+
+// calibratorFor resolves a CalibrationBackend name to its Calibrator,
+// defaulting to the pure-Go benchmark for "", "go", or anything
+// unrecognized (including compiled-language bots, which have no
+// interpreter startup cost to calibrate for).
+func calibratorFor(backend string) Calibrator {
+	switch backend {
+	case "python":
+		return pythonCalibrator{}
+	case "node":
+		return nodeCalibrator{}
+	default:
+		return goCalibrator{}
+	}
+}
+
+// interpreterFamily classifies a bot's configured command executable into
+// a calibration backend.
+func interpreterFamily(executable string) string {
+	switch strings.ToLower(filepath.Base(executable)) {
+	case "python", "python3":
+		return "python"
+	case "node", "nodejs":
+		return "node"
+	default:
+		return "go"
+	}
+}
+
+// detectCalibrationBackend guesses which language family a match's bots
+// are written in from their configured commands, so auto-detected
+// calibration reflects the runtimes actually being judged. Ties and
+// compiled/unrecognized languages fall back to the pure-Go benchmark.
+func detectCalibrationBackend(bots []*BotPlayer) string {
+	counts := map[string]int{}
+	for _, bot := range bots {
+		if len(bot.Config.Command) == 0 {
+			continue
+		}
+		counts[interpreterFamily(bot.Config.Command[0])]++
+	}
+	return mostCommonBackend(counts)
+}
+
+// detectCalibrationBackendFromDirs is detectCalibrationBackend for a
+// Tournament, which only has bot folder names (not yet-constructed
+// BotPlayers) available when it calibrates once up front.
+func detectCalibrationBackendFromDirs(botsDir string, names []string) string {
+	counts := map[string]int{}
+	for _, name := range names {
+		config, err := LoadBotConfig(filepath.Join(botsDir, name))
+		if err != nil || len(config.Command) == 0 {
+			continue
+		}
+		counts[interpreterFamily(config.Command[0])]++
+	}
+	return mostCommonBackend(counts)
+}
+
+func mostCommonBackend(counts map[string]int) string {
+	best, bestCount := "go", 0
+	tied := false
+	for backend, n := range counts {
+		if n > bestCount {
+			best, bestCount, tied = backend, n, false
+		} else if n == bestCount {
+			tied = true
+		}
+	}
+	if tied {
+		return "go"
+	}
+	return best
+}
+
+// goCalibrator runs a small pure-Go CPU microbenchmark in-process - no
+// subprocess, so no interpreter startup overhead to measure. It's the
+// right reference for compiled bots (Go, Rust, C++...) and the fallback
+// when a match's languages can't be detected.
+type goCalibrator struct{}
+
+func (goCalibrator) Backend() string { return "go" }
+
+func (goCalibrator) Calibrate(ctx context.Context) (calibrateResult, error) {
+	start := time.Now()
+	sieveCount(200000)
+	return calibrateResult{Seconds: time.Since(start).Seconds(), Backend: "go"}, nil
+}
+
+// sieveCount counts primes up to n with a simple sieve, just to burn a
+// predictable amount of CPU for goCalibrator.
+func sieveCount(n int) int {
+	composite := make([]bool, n+1)
+	count := 0
+	for i := 2; i <= n; i++ {
+		if composite[i] {
+			continue
+		}
+		count++
+		for j := i * i; j <= n; j += i {
+			composite[j] = true
+		}
+	}
+	return count
+}
+
+// pythonCalibrator shells out to tools/calibrate.py.
+type pythonCalibrator struct{}
+
+func (pythonCalibrator) Backend() string { return "python" }
+
+func (pythonCalibrator) Calibrate(ctx context.Context) (calibrateResult, error) {
+	return runScriptCalibrator(ctx, "python", []string{"python", "python3", "py"}, "tools/calibrate.py")
+}
+
+// nodeCalibrator shells out to tools/calibrate.js.
+type nodeCalibrator struct{}
+
+func (nodeCalibrator) Backend() string { return "node" }
+
+func (nodeCalibrator) Calibrate(ctx context.Context) (calibrateResult, error) {
+	return runScriptCalibrator(ctx, "node", []string{"node", "nodejs"}, "tools/calibrate.js")
+}
+
+// runScriptCalibrator tries each executable name in turn, running it
+// against script and parsing its stdout as {"seconds": <float>}.
+func runScriptCalibrator(ctx context.Context, backend string, executables []string, script string) (calibrateResult, error) {
+	var out []byte
+	var err error
+	for _, exe := range executables {
+		cmd := exec.CommandContext(ctx, exe, script)
+		out, err = cmd.Output()
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return calibrateResult{}, fmt.Errorf("failed to run %s calibrator: %w", backend, err)
+	}
+
+	var parsed struct {
+		Seconds float64 `json:"seconds"`
+	}
+	if err := json.Unmarshal(out, &parsed); err != nil {
+		return calibrateResult{}, fmt.Errorf("failed to parse %s calibrator output: %w", backend, err)
+	}
+
+	return calibrateResult{Seconds: parsed.Seconds, Backend: backend}, nil
+}
+
+type refEntry struct {
+	RefSeconds float64 `json:"ref_seconds"`
+}
+
+func readReferenceBaselines() map[string]refEntry {
+	baselines := map[string]refEntry{}
+	if data, err := os.ReadFile(referenceCalibratePath); err == nil {
+		_ = json.Unmarshal(data, &baselines)
+	}
+	return baselines
+}
+
+func writeReferenceBaselines(baselines map[string]refEntry) error {
+	data, err := json.MarshalIndent(baselines, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(referenceCalibratePath, data, 0644)
+}
+
+// scaleCache remembers each backend's computed scale for the lifetime of
+// the process, so a Tournament scheduling many matches - or several Match
+// instances sharing a backend - only pays for each backend's shell-out
+// once.
+var (
+	scaleCacheMu sync.Mutex
+	scaleCache   = map[string]float64{}
+)
+
+// CalibrateTurnTimeoutScale measures how fast this host runs backend
+// relative to the recorded baseline for that backend in
+// referenceCalibratePath (writing a new baseline the first time a backend
+// is measured), returning a scale factor to apply to TurnTimeout. The
+// measurement runs in a goroutine bounded by calibrationTimeout so a
+// missing interpreter can't stall match startup, and is cached per backend
+// so repeated calls - e.g. one per Match in a Tournament - are free after
+// the first.
+func CalibrateTurnTimeoutScale(backend string) (float64, error) {
+	if backend == "" {
+		backend = "go"
+	}
+
+	scaleCacheMu.Lock()
+	if scale, ok := scaleCache[backend]; ok {
+		scaleCacheMu.Unlock()
+		return scale, nil
+	}
+	scaleCacheMu.Unlock()
+
+	cal := calibratorFor(backend)
+
+	ctx, cancel := context.WithTimeout(context.Background(), calibrationTimeout)
+	defer cancel()
+
+	resultChan := make(chan calibrateResult, 1)
+	errChan := make(chan error, 1)
+	go func() {
+		res, err := cal.Calibrate(ctx)
+		if err != nil {
+			errChan <- err
+			return
+		}
+		resultChan <- res
+	}()
+
+	var res calibrateResult
+	select {
+	case res = <-resultChan:
+	case err := <-errChan:
+		return 0, fmt.Errorf("calibrator %q failed: %w", backend, err)
+	case <-ctx.Done():
+		return 0, fmt.Errorf("calibrator %q timed out after %s", backend, calibrationTimeout)
+	}
+
+	baselines := readReferenceBaselines()
+	entry, ok := baselines[res.Backend]
+	if !ok || entry.RefSeconds <= 0 {
+		entry = refEntry{RefSeconds: res.Seconds}
+		baselines[res.Backend] = entry
+		if err := writeReferenceBaselines(baselines); err != nil {
+			return 0, fmt.Errorf("failed to write reference calibrate file: %w", err)
+		}
+	}
+
+	scale := 1.0
+	if entry.RefSeconds > 0 {
+		scale = res.Seconds / entry.RefSeconds
+	}
+
+	scaleCacheMu.Lock()
+	scaleCache[backend] = scale
+	scaleCacheMu.Unlock()
+
+	return scale, nil
+}
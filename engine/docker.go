@@ -0,0 +1,292 @@
+package engine
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/strslice"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/go-units"
+)
+
+// dockerStopTimeout bounds how long Stop waits for ContainerKill/
+// ContainerRemove to finish, so a hung daemon can't block match teardown.
+const dockerStopTimeout = 5 * time.Second
+
+// startDocker creates, attaches to, and starts a container for this bot via
+// the Docker Engine API, wiring its multiplexed stdio into bp.stdin/
+// bp.stdout/bp.stderr the same way the subprocess path wires up pipes. It
+// replaces shelling out to the docker CLI, which gave no resource telemetry
+// and raced on cleanup (killing the CLI doesn't guarantee the container
+// underneath it stops).
+func (bp *BotPlayer) startDocker() error {
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return fmt.Errorf("failed to create docker client: %w", err)
+	}
+	bp.dockerClient = cli
+
+	ctx := context.Background()
+
+	hostConfig, err := bp.buildHostConfig()
+	if err != nil {
+		return err
+	}
+
+	bp.Logger.Info(bp.logFields(), fmt.Sprintf("creating container: %s (CPUs: %.1f, Memory: %s)", bp.Config.DockerImage, bp.Config.DockerCPUs, bp.Config.DockerMemory))
+
+	created, err := cli.ContainerCreate(ctx, &container.Config{
+		Image:        bp.Config.DockerImage,
+		OpenStdin:    true,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+		StdinOnce:    false,
+		Tty:          false,
+	}, hostConfig, nil, nil, "")
+	if err != nil {
+		return fmt.Errorf("failed to create container: %w", err)
+	}
+	bp.containerID = created.ID
+
+	hijacked, err := cli.ContainerAttach(ctx, bp.containerID, types.ContainerAttachOptions{
+		Stream: true,
+		Stdin:  true,
+		Stdout: true,
+		Stderr: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to attach to container: %w", err)
+	}
+
+	// The attach stream multiplexes stdout/stderr per the Docker stream
+	// protocol (since Tty is false); demultiplex into separate pipes so
+	// the rest of BotPlayer can treat them exactly like subprocess pipes.
+	stdoutReader, stdoutWriter := io.Pipe()
+	stderrReader, stderrWriter := io.Pipe()
+	go func() {
+		_, _ = stdcopy.StdCopy(stdoutWriter, stderrWriter, hijacked.Reader)
+		stdoutWriter.Close()
+		stderrWriter.Close()
+		hijacked.Close()
+	}()
+
+	bp.stdin = hijacked.Conn
+	bp.stdout = io.NopCloser(stdoutReader)
+	bp.stderr = io.NopCloser(stderrReader)
+
+	if err := cli.ContainerStart(ctx, bp.containerID, types.ContainerStartOptions{}); err != nil {
+		return fmt.Errorf("failed to start container: %w", err)
+	}
+
+	bp.scanner = bufio.NewScanner(bp.stdout)
+
+	go bp.consumeDockerStats()
+
+	return nil
+}
+
+// dockerPidsLimit is the PidsLimit applied when a bot's config.json doesn't
+// set one, so a forkbomb-style bot can't exhaust host resources even
+// without an explicit limit configured.
+var dockerPidsLimit int64 = 256
+
+// buildHostConfig translates bp.Config's sandbox fields into a
+// container.HostConfig, applying the hardened defaults (no network,
+// read-only rootfs, all capabilities dropped) a competitive bot arena
+// needs unless the bot's config.json explicitly opts out.
+func (bp *BotPlayer) buildHostConfig() (*container.HostConfig, error) {
+	networkMode := container.NetworkMode("none")
+	if bp.Config.Network {
+		networkMode = "bridge"
+	}
+
+	readOnly := true
+	if bp.Config.ReadOnly != nil {
+		readOnly = *bp.Config.ReadOnly
+	}
+
+	pidsLimit := dockerPidsLimit
+	if bp.Config.PidsLimit > 0 {
+		pidsLimit = bp.Config.PidsLimit
+	}
+
+	hostConfig := &container.HostConfig{
+		NetworkMode:    networkMode,
+		ReadonlyRootfs: readOnly,
+		CapDrop:        strslice.StrSlice{"ALL"},
+		CapAdd:         strslice.StrSlice(bp.Config.Capabilities),
+		Resources: container.Resources{
+			PidsLimit: &pidsLimit,
+		},
+	}
+
+	if len(bp.Config.Tmpfs) > 0 {
+		hostConfig.Tmpfs = make(map[string]string, len(bp.Config.Tmpfs))
+		for _, path := range bp.Config.Tmpfs {
+			hostConfig.Tmpfs[path] = ""
+		}
+	}
+
+	if len(bp.Config.Ulimits) > 0 {
+		ulimits, err := parseUlimits(bp.Config.Ulimits)
+		if err != nil {
+			return nil, err
+		}
+		hostConfig.Resources.Ulimits = ulimits
+	}
+
+	if bp.Config.Seccomp != "" {
+		hostConfig.SecurityOpt = []string{fmt.Sprintf("seccomp=%s", bp.Config.Seccomp)}
+	}
+
+	if bp.Config.DockerCPUs > 0 {
+		hostConfig.Resources.NanoCPUs = int64(bp.Config.DockerCPUs * 1e9)
+	}
+	if bp.Config.DockerMemory != "" {
+		memBytes, err := parseDockerMemory(bp.Config.DockerMemory)
+		if err != nil {
+			return nil, fmt.Errorf("invalid docker_memory %q: %w", bp.Config.DockerMemory, err)
+		}
+		hostConfig.Resources.Memory = memBytes
+	}
+
+	if bp.Directory != "" {
+		if absDir, err := filepath.Abs(bp.Directory); err == nil {
+			hostConfig.Binds = []string{fmt.Sprintf("%s:/bot:ro", absDir)}
+		}
+	}
+
+	return hostConfig, nil
+}
+
+// parseUlimits parses a map of ulimit name to "soft:hard" (or a bare value
+// for soft==hard) into the form the Docker API expects.
+func parseUlimits(limits map[string]string) ([]*units.Ulimit, error) {
+	parsed := make([]*units.Ulimit, 0, len(limits))
+	for name, value := range limits {
+		soft, hard := value, value
+		if i := strings.Index(value, ":"); i >= 0 {
+			soft, hard = value[:i], value[i+1:]
+		}
+
+		softN, err := strconv.ParseInt(soft, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ulimit %q soft value %q: %w", name, soft, err)
+		}
+		hardN, err := strconv.ParseInt(hard, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ulimit %q hard value %q: %w", name, hard, err)
+		}
+
+		parsed = append(parsed, &units.Ulimit{Name: name, Soft: softN, Hard: hardN})
+	}
+	return parsed, nil
+}
+
+// parseDockerMemory parses a Docker-style memory shorthand (e.g. "256m",
+// "1g", or a bare byte count) into a byte count.
+func parseDockerMemory(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("empty memory value")
+	}
+
+	multiplier := int64(1)
+	switch suffix := strings.ToLower(s[len(s)-1:]); suffix {
+	case "k":
+		multiplier = 1024
+		s = s[:len(s)-1]
+	case "m":
+		multiplier = 1024 * 1024
+		s = s[:len(s)-1]
+	case "g":
+		multiplier = 1024 * 1024 * 1024
+		s = s[:len(s)-1]
+	}
+
+	value, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid memory value %q: %w", s, err)
+	}
+
+	return value * multiplier, nil
+}
+
+// consumeDockerStats streams ContainerStats in the background for as long
+// as the container runs, populating bp.CPUPercent/bp.MemBytes so GetStats
+// can surface live resource telemetry per bot.
+func (bp *BotPlayer) consumeDockerStats() {
+	stats, err := bp.dockerClient.ContainerStats(context.Background(), bp.containerID, true)
+	if err != nil {
+		fields := bp.logFields()
+		fields.Err = err
+		bp.Logger.Error(fields, "failed to stream container stats")
+		return
+	}
+	defer stats.Body.Close()
+
+	decoder := json.NewDecoder(bufio.NewReader(stats.Body))
+	var prev types.StatsJSON
+	for {
+		var v types.StatsJSON
+		if err := decoder.Decode(&v); err != nil {
+			return
+		}
+
+		cpuDelta := float64(v.CPUStats.CPUUsage.TotalUsage - prev.CPUStats.CPUUsage.TotalUsage)
+		systemDelta := float64(v.CPUStats.SystemUsage - prev.CPUStats.SystemUsage)
+		var cpuPercent float64
+		if systemDelta > 0 && cpuDelta > 0 {
+			onlineCPUs := float64(v.CPUStats.OnlineCPUs)
+			if onlineCPUs == 0 {
+				onlineCPUs = float64(len(v.CPUStats.CPUUsage.PercpuUsage))
+			}
+			if onlineCPUs == 0 {
+				onlineCPUs = 1
+			}
+			cpuPercent = (cpuDelta / systemDelta) * onlineCPUs * 100.0
+		}
+		prev = v
+
+		bp.statsMu.Lock()
+		bp.CPUPercent = cpuPercent
+		bp.MemBytes = v.MemoryStats.Usage
+		bp.statsMu.Unlock()
+	}
+}
+
+// stopDocker kills and removes this bot's container, bounded by
+// dockerStopTimeout (or ctx, if it's canceled first) so a hung daemon can't
+// block match teardown.
+func (bp *BotPlayer) stopDocker(ctx context.Context) error {
+	if bp.dockerClient == nil || bp.containerID == "" {
+		return nil
+	}
+	defer bp.dockerClient.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, dockerStopTimeout)
+	defer cancel()
+
+	if err := bp.dockerClient.ContainerKill(ctx, bp.containerID, "SIGKILL"); err != nil {
+		fields := bp.logFields()
+		fields.Err = err
+		bp.Logger.Error(fields, "failed to kill container")
+	}
+
+	if err := bp.dockerClient.ContainerRemove(ctx, bp.containerID, types.ContainerRemoveOptions{Force: true}); err != nil {
+		return fmt.Errorf("failed to remove container: %w", err)
+	}
+
+	return nil
+}
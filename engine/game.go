@@ -4,6 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/rand"
+	"sort"
+	"time"
+
+	"snakegame/engine/bitboard"
 )
 
 // Direction represents movement direction
@@ -24,7 +28,7 @@ const (
 
 	AppleGod    AppleType = "GOD"    // God apple: +3 points (counts as 3 length)
 	AppleSpeed  AppleType = "SPEED"  // Speed apple: 2 moves per turn for next 5 turns
-	AppleSleep  AppleType = "SLEEP"  // Sleep apple: freeze opponent for 5 turns
+	AppleSleep  AppleType = "SLEEP"  // Sleep apple: freeze opponent(s) for 5 turns
 	ApplePoison AppleType = "POISON" // Poison apple: -1 length and -1 score
 )
 
@@ -48,23 +52,53 @@ type Snake struct {
 	Direction   Direction  `json:"direction"`
 	Alive       bool       `json:"alive"`
 	Length      int        `json:"length"`
-	Score       int        `json:"score"`        // Score from eating apples
-	SpeedTurns  int        `json:"speed_turns"`  // Remaining turns with 2x speed
-	SleepTurns  int        `json:"sleep_turns"`  // Remaining turns frozen
-	Energy      int        `json:"energy"`       // Energy depletes by 1 per turn, eating apple restores to 60
-	DeathReason string     `json:"death_reason"` // Reason for death: "wall", "self", "body", "head-to-head", "hunger", "obstacle"
+	Score       int        `json:"score"`           // Score from eating apples
+	SpeedTurns  int        `json:"speed_turns"`     // Remaining turns with 2x speed
+	SleepTurns  int        `json:"sleep_turns"`     // Remaining turns frozen
+	Energy      int        `json:"energy"`          // Energy depletes by 1 per turn, eating apple restores to 60
+	DeathReason string     `json:"death_reason"`    // Reason for death: "wall", "self", "body", "head-to-head", "hunger", "obstacle"
+	Shout       string     `json:"shout,omitempty"` // Last message this snake's bot attached to its move, visible to the opponent on the next turn
+}
+
+// maxShoutBytes caps how much of a MoveDecision's Shout is kept, so a
+// misbehaving bot can't bloat every subsequent ToJSON payload.
+const maxShoutBytes = 256
+
+// MoveDecision is a move paired with an optional short message for the
+// opponent, delivered via the next turn's ToJSON payload and cleared once
+// delivered.
+type MoveDecision struct {
+	Direction Direction `json:"direction"`
+	Shout     string    `json:"shout,omitempty"`
 }
 
-// GameState represents the complete state of the game
+// GameState represents the complete state of the game. Snakes holds one
+// entry per player in a match - two for a classic head-to-head game, more
+// for a free-for-all.
 type GameState struct {
-	Turn       int       `json:"turn"`
-	GridWidth  int       `json:"grid_width"`
-	GridHeight int       `json:"grid_height"`
-	Snakes     [2]*Snake `json:"snakes"`
-	Apples     []Apple   `json:"apples"`
-	Map        *Map      `json:"map"`
-	Winner     int       `json:"winner"` // 0 = no winner yet, 1 or 2 = winner
-	GameOver   bool      `json:"game_over"`
+	Turn       int      `json:"turn"`
+	GridWidth  int      `json:"grid_width"`
+	GridHeight int      `json:"grid_height"`
+	Snakes     []*Snake `json:"snakes"`
+	Apples     []Apple  `json:"apples"`
+	Map        *Map     `json:"map"`
+	Winner     int      `json:"winner"` // 0 = no winner yet/draw, otherwise a Snake.ID
+	GameOver   bool     `json:"game_over"`
+
+	// rng is the source of randomness for this state (currently just apple
+	// spawning). It is unexported and excluded from JSON so that bots can't
+	// observe or tamper with it through ToJSON.
+	rng *rand.Rand
+	// seed is the value rng was originally constructed from, kept around so
+	// callers (e.g. Replay) can record how to reproduce this exact game.
+	seed int64
+}
+
+// Seed returns the seed this game state's RNG was originally constructed
+// from (see NewGameStateWithSeed). It does not change when SetRNG is called
+// directly or when Clone derives a new stream for a cloned state.
+func (gs *GameState) Seed() int64 {
+	return gs.seed
 }
 
 // Map represents static map data like obstacles
@@ -74,53 +108,114 @@ type Map struct {
 	Obstacles []Position `json:"obstacles"`
 }
 
-// NewGameState creates a new game with initial snake positions
-// The optional param m can be nil for an empty map
+// NewGameState creates a new two-player game with initial snake positions.
+// The optional param m can be nil for an empty map.
 func NewGameState(width, height int, m *Map) *GameState {
+	return NewGameStateWithSeed(width, height, m, time.Now().UnixNano())
+}
+
+// NewGameStateWithSeed creates a new two-player game exactly like
+// NewGameState, but seeds its RNG explicitly so apple spawning (and any
+// future randomness) is reproducible. This is the entry point simulations
+// and AI playouts should use instead of NewGameState, since thousands of
+// independent rollouts must not share a single RNG stream.
+func NewGameStateWithSeed(width, height int, m *Map, seed int64) *GameState {
+	return NewGameStateForBots(width, height, m, seed, 2)
+}
+
+// startingSlot is a perimeter spawn point: a head position and the
+// direction the snake starts facing (its body trails behind it).
+type startingSlot struct {
+	head Position
+	dir  Direction
+}
+
+// startingSlots returns up to count perimeter slots for a board of the
+// given size, starting with the two corners the engine has always used so
+// NewGameStateForBots(..., 2) matches the original two-player layout
+// exactly. Additional players fill the remaining corners, then edge
+// midpoints.
+func startingSlots(width, height, count int) []startingSlot {
+	all := []startingSlot{
+		{Position{X: 1, Y: 2}, DirectionDown},                  // top-left
+		{Position{X: width - 2, Y: height - 3}, DirectionUp},   // bottom-right
+		{Position{X: width - 2, Y: 2}, DirectionDown},          // top-right
+		{Position{X: 1, Y: height - 3}, DirectionUp},           // bottom-left
+		{Position{X: width / 2, Y: 2}, DirectionDown},          // top-mid
+		{Position{X: width / 2, Y: height - 3}, DirectionUp},   // bottom-mid
+		{Position{X: 1, Y: height / 2}, DirectionRight},        // left-mid
+		{Position{X: width - 2, Y: height / 2}, DirectionLeft}, // right-mid
+	}
+
+	slots := make([]startingSlot, count)
+	for i := 0; i < count; i++ {
+		slots[i] = all[i%len(all)]
+	}
+	return slots
+}
+
+// directionStep returns the unit vector a snake moving in dir advances by.
+func directionStep(dir Direction) (dx, dy int) {
+	switch dir {
+	case DirectionUp:
+		return 0, -1
+	case DirectionDown:
+		return 0, 1
+	case DirectionLeft:
+		return -1, 0
+	case DirectionRight:
+		return 1, 0
+	}
+	return 0, 0
+}
+
+// buildBody lays out length segments behind head, trailing opposite dir -
+// the same shape NewGameState has always given its two starting snakes.
+func buildBody(head Position, dir Direction, length int) []Position {
+	dx, dy := directionStep(dir)
+	body := make([]Position, length)
+	pos := head
+	for i := 0; i < length; i++ {
+		body[i] = pos
+		pos = Position{X: pos.X - dx, Y: pos.Y - dy}
+	}
+	return body
+}
+
+// NewGameStateForBots creates a new game sized for numBots players, placing
+// their starting snakes around the board perimeter. numBots must be at
+// least 1; passing 2 reproduces NewGameStateWithSeed's classic layout.
+func NewGameStateForBots(width, height int, m *Map, seed int64, numBots int) *GameState {
+	if numBots < 1 {
+		numBots = 1
+	}
+
 	gs := &GameState{
 		Turn:       0,
 		GridWidth:  width,
 		GridHeight: height,
+		Snakes:     make([]*Snake, numBots),
 		Apples:     []Apple{},
 		Map:        m,
 		Winner:     0,
 		GameOver:   false,
+		rng:        rand.New(rand.NewSource(seed)),
+		seed:       seed,
 	}
 
-	// Initialize Snake 1 (top-left corner)
-	gs.Snakes[0] = &Snake{
-		ID: 1,
-		Body: []Position{
-			{X: 1, Y: 2},
-			{X: 1, Y: 1},
-			{X: 1, Y: 0},
-		},
-		Direction:   DirectionDown,
-		Alive:       true,
-		Length:      3,
-		Score:       0,
-		SpeedTurns:  0,
-		SleepTurns:  0,
-		Energy:      60,
-		DeathReason: "",
-	}
-
-	// Initialize Snake 2 (bottom-right corner)
-	gs.Snakes[1] = &Snake{
-		ID: 2,
-		Body: []Position{
-			{X: width - 2, Y: height - 3},
-			{X: width - 2, Y: height - 2},
-			{X: width - 2, Y: height - 1},
-		},
-		Direction:   DirectionUp,
-		Alive:       true,
-		Length:      3,
-		Score:       0,
-		SpeedTurns:  0,
-		SleepTurns:  0,
-		Energy:      60,
-		DeathReason: "",
+	for i, slot := range startingSlots(width, height, numBots) {
+		gs.Snakes[i] = &Snake{
+			ID:          i + 1,
+			Body:        buildBody(slot.head, slot.dir, 3),
+			Direction:   slot.dir,
+			Alive:       true,
+			Length:      3,
+			Score:       0,
+			SpeedTurns:  0,
+			SleepTurns:  0,
+			Energy:      60,
+			DeathReason: "",
+		}
 	}
 
 	// Spawn initial apples
@@ -131,20 +226,29 @@ func NewGameState(width, height int, m *Map) *GameState {
 	return gs
 }
 
-// ToJSON converts the game state to JSON for bot communication
-// botID indicates which bot is receiving this state (1 or 2)
-// The snakes array is reordered so the receiving bot's snake is always at index 0
+// SetRNG replaces the game state's random source, e.g. so a test or an AI
+// playout can pin it to a known seed after construction.
+func (gs *GameState) SetRNG(r *rand.Rand) {
+	gs.rng = r
+}
+
+// ToJSON converts the game state to JSON for bot communication.
+// botID indicates which bot is receiving this state (1-indexed). The
+// snakes array is reordered so the receiving bot's snake is always at
+// index 0; the rest keep their original relative order.
 func (gs *GameState) ToJSON(botID int) ([]byte, error) {
 	// Create a copy of the game state
 	stateForBot := *gs
 
-	// Reorder snakes so the receiving bot's snake is at index 0
-	if botID == 2 {
-		// Bot 2: swap order [snake2, snake1]
-		stateForBot.Snakes[0] = gs.Snakes[1]
-		stateForBot.Snakes[1] = gs.Snakes[0]
+	reordered := make([]*Snake, 0, len(gs.Snakes))
+	reordered = append(reordered, gs.Snakes[botID-1])
+	for i, snake := range gs.Snakes {
+		if i == botID-1 {
+			continue
+		}
+		reordered = append(reordered, snake)
 	}
-	// Bot 1: original order is already correct
+	stateForBot.Snakes = reordered
 
 	return json.Marshal(stateForBot)
 }
@@ -215,8 +319,18 @@ func (s *Snake) getNextPosition(pos Position, dir Direction) Position {
 	return pos
 }
 
-// CheckCollision checks if snake collided with walls, itself, or other snake
+// CheckCollision checks if snake collided with walls, itself, or another
+// snake's body.
 func (gs *GameState) CheckCollision(snakeID int) bool {
+	return gs.checkCollision(snakeID, gs.ToBitboard())
+}
+
+// checkCollision is CheckCollision's implementation, taking an
+// already-built bitboard (or nil, to fall back to the scanning path below)
+// so a caller checking every snake in a turn - ProcessTurnN - can build the
+// bitboard once per movement step and reuse it across every snake's check
+// instead of paying to rebuild it per snake.
+func (gs *GameState) checkCollision(snakeID int, bb *bitboard.Board) bool {
 	snake := gs.Snakes[snakeID-1]
 	if !snake.Alive {
 		return false
@@ -231,6 +345,12 @@ func (gs *GameState) CheckCollision(snakeID int) bool {
 		return true
 	}
 
+	if bb != nil {
+		return gs.checkCollisionBitboard(bb, snakeID, head)
+	}
+
+	self := snakeID - 1
+
 	// Check self-collision (skip the head itself)
 	for i := 1; i < len(snake.Body); i++ {
 		if head.X == snake.Body[i].X && head.Y == snake.Body[i].Y {
@@ -240,16 +360,22 @@ func (gs *GameState) CheckCollision(snakeID int) bool {
 		}
 	}
 
-	// Check collision with other snake's body (skip head for head-to-head check)
-	otherSnake := gs.Snakes[(snakeID % 2)] // 1->0, 2->1
-	for i, segment := range otherSnake.Body {
-		if i == 0 {
-			continue // Skip head, handled by head-to-head collision check
+	// Check collision with every other snake's body (skip their heads -
+	// head-to-head is handled separately so two equally-fast heads can
+	// meet without this loop falsely flagging a body collision).
+	for i, other := range gs.Snakes {
+		if i == self {
+			continue
 		}
-		if head.X == segment.X && head.Y == segment.Y {
-			snake.Alive = false
-			snake.DeathReason = "body"
-			return true
+		for j, segment := range other.Body {
+			if j == 0 {
+				continue
+			}
+			if head.X == segment.X && head.Y == segment.Y {
+				snake.Alive = false
+				snake.DeathReason = "body"
+				return true
+			}
 		}
 	}
 
@@ -267,8 +393,50 @@ func (gs *GameState) CheckCollision(snakeID int) bool {
 	return false
 }
 
+// checkCollisionBitboard is the O(1)-per-query fast path for CheckCollision
+// on boards small enough to fit bitboard.MaxDimension. The wall check has
+// already happened by the time this is called.
+func (gs *GameState) checkCollisionBitboard(bb *bitboard.Board, snakeID int, head Position) bool {
+	snake := gs.Snakes[snakeID-1]
+	self := snakeID - 1
+	idx := bb.Index(head.X, head.Y)
+
+	if bb.Trailing[self].Test(idx) {
+		snake.Alive = false
+		snake.DeathReason = "self"
+		return true
+	}
+
+	for i := range gs.Snakes {
+		if i == self {
+			continue
+		}
+		if bb.Trailing[i].Test(idx) {
+			snake.Alive = false
+			snake.DeathReason = "body"
+			return true
+		}
+	}
+
+	if bb.IsObstacle(idx) {
+		snake.Alive = false
+		snake.DeathReason = "obstacle"
+		return true
+	}
+
+	return false
+}
+
 // CheckAppleEaten checks if snake ate an apple and returns the apple type
 func (gs *GameState) CheckAppleEaten(snakeID int) (bool, AppleType) {
+	return gs.checkAppleEaten(snakeID, gs.ToBitboard())
+}
+
+// checkAppleEaten is CheckAppleEaten's implementation, taking an
+// already-built bitboard (or nil) so ProcessTurnN can build one bitboard
+// and reuse it across every snake's check instead of rebuilding it inside
+// each one.
+func (gs *GameState) checkAppleEaten(snakeID int, bb *bitboard.Board) (bool, AppleType) {
 	snake := gs.Snakes[snakeID-1]
 	if !snake.Alive {
 		return false, ""
@@ -276,6 +444,12 @@ func (gs *GameState) CheckAppleEaten(snakeID int) (bool, AppleType) {
 
 	head := snake.GetHead()
 
+	if bb != nil {
+		if _, ok := bb.AppleAt(bb.Index(head.X, head.Y)); !ok {
+			return false, ""
+		}
+	}
+
 	for i, apple := range gs.Apples {
 		if head.X == apple.X && head.Y == apple.Y {
 			// Remove eaten apple
@@ -288,6 +462,15 @@ func (gs *GameState) CheckAppleEaten(snakeID int) (bool, AppleType) {
 	return false, ""
 }
 
+// truncateShout caps a shout at maxShoutBytes so a bot can't grow every
+// subsequent ToJSON payload without bound.
+func truncateShout(s string) string {
+	if len(s) <= maxShoutBytes {
+		return s
+	}
+	return s[:maxShoutBytes]
+}
+
 // manhattanDistance calculates the Manhattan distance between two positions
 func manhattanDistance(p1, p2 Position) int {
 	dx := p1.X - p2.X
@@ -301,119 +484,131 @@ func manhattanDistance(p1, p2 Position) int {
 	return dx + dy
 }
 
-// SpawnApple spawns a new apple using zone-based balanced spawning
-// Positions are categorized as: Snake1 territory, Snake2 territory, or Neutral
-// This ensures fair distribution and reduces spawn RNG
+// appleZone classifies pos as belonging to the territory of the snake whose
+// head it's closest to (returned as that snake's index), or to the neutral
+// zone (-1) if pos's distances to the two closest heads differ by at most 3
+// tiles (and always with fewer than two snakes).
+func appleZone(pos Position, heads []Position) int {
+	type distance struct {
+		snake int
+		dist  int
+	}
+	dists := make([]distance, len(heads))
+	for i, head := range heads {
+		dists[i] = distance{snake: i, dist: manhattanDistance(pos, head)}
+	}
+	sort.Slice(dists, func(i, j int) bool { return dists[i].dist < dists[j].dist })
+
+	if len(dists) < 2 || dists[1].dist-dists[0].dist <= 3 {
+		return -1
+	}
+	return dists[0].snake
+}
+
+// SpawnApple spawns a new apple using zone-based balanced spawning: each
+// empty cell belongs to whichever snake's head is nearest, or to a neutral
+// zone if the two nearest heads are roughly equidistant. Apples are spawned
+// into whichever zone currently holds the fewest, so territory fills out
+// fairly regardless of how many snakes are in play.
 func (gs *GameState) SpawnApple() {
-	// Build set of occupied positions
-	occupied := make(map[Position]bool)
+	if gs.rng == nil {
+		// Defensive fallback for GameStates built outside NewGameState(WithSeed),
+		// e.g. via JSON decoding, where the unexported rng can't be populated.
+		gs.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
 
-	// Add snake segments
-	for _, snake := range gs.Snakes {
-		for _, segment := range snake.Body {
-			occupied[segment] = true
+	// Build set of occupied positions. On boards that fit the bitboard
+	// (<= 19x19), use it for the occupancy test instead of a Go map - the
+	// zone scan below probes every cell on the board, so this is the
+	// hottest loop in apple spawning.
+	bb := gs.ToBitboard()
+
+	var occupied map[Position]bool
+	if bb == nil {
+		occupied = make(map[Position]bool)
+
+		for _, snake := range gs.Snakes {
+			for _, segment := range snake.Body {
+				occupied[segment] = true
+			}
 		}
-	}
 
-	// Add existing apples
-	for _, apple := range gs.Apples {
-		occupied[Position{X: apple.X, Y: apple.Y}] = true
+		for _, apple := range gs.Apples {
+			occupied[Position{X: apple.X, Y: apple.Y}] = true
+		}
+
+		if gs.Map != nil {
+			for _, obs := range gs.Map.Obstacles {
+				occupied[obs] = true
+			}
+		}
 	}
 
-	// Add map obstacles
-	if gs.Map != nil {
-		for _, obs := range gs.Map.Obstacles {
-			occupied[obs] = true
+	isOccupied := func(pos Position) bool {
+		if bb != nil {
+			idx := bb.Index(pos.X, pos.Y)
+			if bb.Occupied(idx) {
+				return true
+			}
+			_, hasApple := bb.AppleAt(idx)
+			return hasApple
 		}
+		return occupied[pos]
 	}
 
-	// Get snake heads for distance calculation
-	head1 := gs.Snakes[0].GetHead()
-	head2 := gs.Snakes[1].GetHead()
+	heads := make([]Position, len(gs.Snakes))
+	for i, snake := range gs.Snakes {
+		heads[i] = snake.GetHead()
+	}
 
-	// Categorize all empty positions into zones
-	var snake1Positions []Position  // Closer to snake 1
-	var snake2Positions []Position  // Closer to snake 2
-	var neutralPositions []Position // Roughly equidistant (within 3 tiles)
+	// zonePositions/zoneAppleCounts are keyed by snake index, with -1 for
+	// the neutral zone.
+	zonePositions := make(map[int][]Position)
+	zoneAppleCounts := make(map[int]int)
 
 	for y := 0; y < gs.GridHeight; y++ {
 		for x := 0; x < gs.GridWidth; x++ {
 			pos := Position{X: x, Y: y}
-			if !occupied[pos] {
-				dist1 := manhattanDistance(pos, head1)
-				dist2 := manhattanDistance(pos, head2)
-				distDiff := dist1 - dist2
-				if distDiff < 0 {
-					distDiff = -distDiff
-				}
-
-				if distDiff <= 3 {
-					// Roughly equidistant (within 3 tiles difference)
-					neutralPositions = append(neutralPositions, pos)
-				} else if dist1 < dist2 {
-					snake1Positions = append(snake1Positions, pos)
-				} else {
-					snake2Positions = append(snake2Positions, pos)
-				}
+			if !isOccupied(pos) {
+				zone := appleZone(pos, heads)
+				zonePositions[zone] = append(zonePositions[zone], pos)
 			}
 		}
 	}
 
-	// Count existing apples in each zone to balance spawning
-	snake1AppleCount := 0
-	snake2AppleCount := 0
-	neutralAppleCount := 0
-
 	for _, apple := range gs.Apples {
-		applePos := Position{X: apple.X, Y: apple.Y}
-		dist1 := manhattanDistance(applePos, head1)
-		dist2 := manhattanDistance(applePos, head2)
-		distDiff := dist1 - dist2
-		if distDiff < 0 {
-			distDiff = -distDiff
-		}
-
-		if distDiff <= 3 {
-			neutralAppleCount++
-		} else if dist1 < dist2 {
-			snake1AppleCount++
-		} else {
-			snake2AppleCount++
-		}
+		zone := appleZone(Position{X: apple.X, Y: apple.Y}, heads)
+		zoneAppleCounts[zone]++
 	}
 
-	// Select zone to spawn in based on current distribution
-	// Priority: spawn in zone with fewest apples for balance
-	var selectedPositions []Position
-
-	// Find the zone with minimum apple count
-	selectedPositions = neutralPositions
-
-	if snake2AppleCount < snake1AppleCount && len(snake2Positions) > 0 {
-		selectedPositions = snake2Positions
-	} else if snake1AppleCount < snake2AppleCount && len(snake1Positions) > 0 {
-		selectedPositions = snake1Positions
+	// Select the non-empty zone with the fewest existing apples, breaking
+	// ties toward the lowest zone key (-1, the neutral zone, first).
+	candidateZones := make([]int, 0, len(zonePositions))
+	for zone, positions := range zonePositions {
+		if len(positions) > 0 {
+			candidateZones = append(candidateZones, zone)
+		}
 	}
+	sort.Ints(candidateZones)
 
-	// Fallback: if selected zone is empty, try other zones
-	if len(selectedPositions) == 0 {
-		if len(neutralPositions) > 0 {
-			selectedPositions = neutralPositions
-		} else if len(snake1Positions) > 0 {
-			selectedPositions = snake1Positions
-		} else if len(snake2Positions) > 0 {
-			selectedPositions = snake2Positions
+	var selectedPositions []Position
+	bestCount := -1
+	for _, zone := range candidateZones {
+		count := zoneAppleCounts[zone]
+		if bestCount == -1 || count < bestCount {
+			bestCount = count
+			selectedPositions = zonePositions[zone]
 		}
 	}
 
 	// Spawn apple in selected zone
 	if len(selectedPositions) > 0 {
-		randomIndex := rand.Intn(len(selectedPositions))
+		randomIndex := gs.rng.Intn(len(selectedPositions))
 		pos := selectedPositions[randomIndex]
 
 		// Randomly select apple type (weighted distribution)
 		// 60% NORMAL, 15% GOD, 15% SPEED, 5% SLEEP, 5% POISON
-		rng := rand.Intn(100)
+		rng := gs.rng.Intn(100)
 		appleType := AppleNormal
 		if rng < 60 {
 			appleType = AppleNormal
@@ -431,206 +626,237 @@ func (gs *GameState) SpawnApple() {
 	}
 }
 
-// checkGameOver determines if the game is over and sets winner
+// checkGameOver determines if the game is over and sets the winner. The
+// game ends as soon as at most one snake remains alive: that snake wins,
+// or - if everyone died on the same turn - whichever had the greatest
+// length (a tie there is a draw, Winner 0).
 func (gs *GameState) checkGameOver() {
-	snake1Alive := gs.Snakes[0].Alive
-	snake2Alive := gs.Snakes[1].Alive
-
-	if !snake1Alive && !snake2Alive {
-		gs.GameOver = true
-		// Determine winner by length
-		if gs.Snakes[0].Length > gs.Snakes[1].Length {
-			gs.Winner = 1
-		} else if gs.Snakes[1].Length > gs.Snakes[0].Length {
-			gs.Winner = 2
-		} else {
-			gs.Winner = 0 // Draw
+	aliveCount := 0
+	lastAlive := -1
+	for i, snake := range gs.Snakes {
+		if snake.Alive {
+			aliveCount++
+			lastAlive = i
 		}
-	} else if !snake1Alive {
-		gs.GameOver = true
-		gs.Winner = 2
-	} else if !snake2Alive {
-		gs.GameOver = true
-		gs.Winner = 1
+	}
+
+	if aliveCount > 1 {
+		return
+	}
+
+	gs.GameOver = true
+
+	if aliveCount == 1 {
+		gs.Winner = gs.Snakes[lastAlive].ID
+		return
+	}
+
+	best := 0
+	tie := false
+	for i := 1; i < len(gs.Snakes); i++ {
+		if gs.Snakes[i].Length > gs.Snakes[best].Length {
+			best = i
+			tie = false
+		} else if gs.Snakes[i].Length == gs.Snakes[best].Length {
+			tie = true
+		}
+	}
+	if tie {
+		gs.Winner = 0
+	} else {
+		gs.Winner = gs.Snakes[best].ID
 	}
 }
 
-// ProcessTurn processes one turn of the game
+// ProcessTurn processes one turn of the game using direction-only moves
+// for the classic two-player case. It is a thin, backwards-compatible
+// wrapper over ProcessTurnWithShout for callers that don't care about the
+// shout channel.
 func (gs *GameState) ProcessTurn(move1, move2 Direction) {
+	gs.ProcessTurnWithShout(MoveDecision{Direction: move1}, MoveDecision{Direction: move2})
+}
+
+// ProcessTurnWithShout processes one turn of a two-player game, additionally
+// recording each bot's shout so it can be read by the opponent in next
+// turn's ToJSON payload. It is a thin wrapper over the N-player
+// ProcessTurnN.
+func (gs *GameState) ProcessTurnWithShout(move1, move2 MoveDecision) {
+	gs.ProcessTurnN([]MoveDecision{move1, move2})
+}
+
+// ProcessTurnN processes one turn for an arbitrary number of snakes. moves
+// is indexed the same way as gs.Snakes; a missing entry is treated as "hold
+// current direction, say nothing".
+func (gs *GameState) ProcessTurnN(moves []MoveDecision) {
 	gs.Turn++
 
-	// Move snakes (once or twice if speed is active)
-	// Each snake moves independently based on their speed state
-	movesToMake1 := 1
-	movesToMake2 := 1
-	if gs.Snakes[0].SpeedTurns > 0 {
-		movesToMake1 = 2
-	}
-	if gs.Snakes[1].SpeedTurns > 0 {
-		movesToMake2 = 2
-	}
-
-	// Determine max moves needed for the loop
-	maxMoves := movesToMake1
-	if movesToMake2 > maxMoves {
-		maxMoves = movesToMake2
-	}
-
-	for moveCount := 0; moveCount < maxMoves; moveCount++ {
-		// Only move if not frozen and still have moves remaining
-		if gs.Snakes[0].SleepTurns <= 0 && moveCount < movesToMake1 {
-			gs.Snakes[0].Move(move1, false)
-		}
-		if gs.Snakes[1].SleepTurns <= 0 && moveCount < movesToMake2 {
-			gs.Snakes[1].Move(move2, false)
-		}
-
-		// Check for collisions
-		collision1 := gs.CheckCollision(1)
-		collision2 := gs.CheckCollision(2)
-
-		// Check for head-to-head collision
-		if gs.Snakes[0].Alive && gs.Snakes[1].Alive {
-			head1 := gs.Snakes[0].GetHead()
-			head2 := gs.Snakes[1].GetHead()
-			if head1.X == head2.X && head1.Y == head2.Y {
-				// Head-to-head collision: smaller snake dies, equal length = both die
-				if gs.Snakes[0].Length > gs.Snakes[1].Length {
-					// Snake 1 is longer, Snake 2 dies
-					gs.Snakes[1].Alive = false
-					gs.Snakes[1].DeathReason = "head-to-head"
-					collision2 = true
-				} else if gs.Snakes[1].Length > gs.Snakes[0].Length {
-					// Snake 2 is longer, Snake 1 dies
-					gs.Snakes[0].Alive = false
-					gs.Snakes[0].DeathReason = "head-to-head"
-					collision1 = true
-				} else {
-					// Equal length: both die
-					gs.Snakes[0].Alive = false
-					gs.Snakes[0].DeathReason = "head-to-head"
-					gs.Snakes[1].Alive = false
-					gs.Snakes[1].DeathReason = "head-to-head"
-					collision1 = true
-					collision2 = true
+	n := len(gs.Snakes)
+	movesToMake := make([]int, n)
+	maxMoves := 1
+	for i, snake := range gs.Snakes {
+		movesToMake[i] = 1
+		if snake.SpeedTurns > 0 {
+			movesToMake[i] = 2
+		}
+		if movesToMake[i] > maxMoves {
+			maxMoves = movesToMake[i]
+		}
+	}
+
+	for step := 0; step < maxMoves; step++ {
+		for i, snake := range gs.Snakes {
+			if snake.SleepTurns <= 0 && step < movesToMake[i] {
+				dir := snake.Direction
+				if i < len(moves) {
+					dir = moves[i].Direction
 				}
+				snake.Move(dir, false)
 			}
 		}
 
-		if collision1 || collision2 {
-			break // Stop if collision occurs
+		// Build the bitboard once for this step and reuse it across every
+		// snake's collision check, instead of each CheckCollision call
+		// rebuilding it from scratch.
+		stepBB := gs.ToBitboard()
+
+		collided := make([]bool, n)
+		for i := range gs.Snakes {
+			if gs.checkCollision(i+1, stepBB) {
+				collided[i] = true
+			}
 		}
-	}
 
-	// Track which snakes ate apples this turn (to skip energy depletion)
-	snake1AteApple := false
-	snake2AteApple := false
+		// Multi-way head-to-head: group alive snakes sharing a head cell.
+		// The largest snake in the group survives, winning ties by being
+		// first among the tied snakes; everyone else dies.
+		headGroups := make(map[Position][]int)
+		for i, snake := range gs.Snakes {
+			if snake.Alive {
+				headGroups[snake.GetHead()] = append(headGroups[snake.GetHead()], i)
+			}
+		}
+		for _, group := range headGroups {
+			if len(group) < 2 {
+				continue
+			}
+			survivor := group[0]
+			for _, idx := range group[1:] {
+				if gs.Snakes[idx].Length > gs.Snakes[survivor].Length {
+					survivor = idx
+				}
+			}
+			for _, idx := range group {
+				if idx == survivor {
+					continue
+				}
+				gs.Snakes[idx].Alive = false
+				gs.Snakes[idx].DeathReason = "head-to-head"
+				collided[idx] = true
+			}
+		}
 
-	// Check for apple consumption and apply effects
-	if gs.Snakes[0].Alive {
-		eaten, appleType := gs.CheckAppleEaten(1)
-		if eaten {
-			gs.ApplyAppleEffect(1, appleType)
-			gs.SpawnApple()
-			snake1AteApple = true
+		anyCollision := false
+		for _, c := range collided {
+			if c {
+				anyCollision = true
+				break
+			}
+		}
+		if anyCollision {
+			break
 		}
 	}
 
-	if gs.Snakes[1].Alive {
-		eaten, appleType := gs.CheckAppleEaten(2)
-		if eaten {
-			gs.ApplyAppleEffect(2, appleType)
-			gs.SpawnApple()
-			snake2AteApple = true
+	// Check for apple consumption and apply effects. Built once and reused
+	// across every snake's check, like the per-step collision bitboard above.
+	appleBB := gs.ToBitboard()
+	ateApple := make([]bool, n)
+	for i := range gs.Snakes {
+		if gs.Snakes[i].Alive {
+			eaten, appleType := gs.checkAppleEaten(i+1, appleBB)
+			if eaten {
+				gs.ApplyAppleEffect(i+1, appleType)
+				gs.SpawnApple()
+				ateApple[i] = true
+			}
 		}
 	}
 
 	// Decrement speed and sleep timers AFTER movement and effects
-	if gs.Snakes[0].SpeedTurns > 0 {
-		gs.Snakes[0].SpeedTurns--
-	}
-	if gs.Snakes[1].SpeedTurns > 0 {
-		gs.Snakes[1].SpeedTurns--
-	}
-	if gs.Snakes[0].SleepTurns > 0 {
-		gs.Snakes[0].SleepTurns--
-	}
-	if gs.Snakes[1].SleepTurns > 0 {
-		gs.Snakes[1].SleepTurns--
+	for _, snake := range gs.Snakes {
+		if snake.SpeedTurns > 0 {
+			snake.SpeedTurns--
+		}
+		if snake.SleepTurns > 0 {
+			snake.SleepTurns--
+		}
 	}
 
 	// Deplete energy by 1 per turn for alive snakes (but not if they ate an apple this turn)
-	if gs.Snakes[0].Alive && !snake1AteApple {
-		gs.Snakes[0].Energy--
-		if gs.Snakes[0].Energy <= 0 {
-			gs.Snakes[0].Alive = false
-			gs.Snakes[0].DeathReason = "hunger"
+	for i, snake := range gs.Snakes {
+		if snake.Alive && !ateApple[i] {
+			snake.Energy--
+			if snake.Energy <= 0 {
+				snake.Alive = false
+				snake.DeathReason = "hunger"
+			}
 		}
 	}
-	if gs.Snakes[1].Alive && !snake2AteApple {
-		gs.Snakes[1].Energy--
-		if gs.Snakes[1].Energy <= 0 {
-			gs.Snakes[1].Alive = false
-			gs.Snakes[1].DeathReason = "hunger"
+
+	// Record this turn's shouts, replacing whatever was delivered last turn.
+	for i, snake := range gs.Snakes {
+		shout := ""
+		if i < len(moves) {
+			shout = moves[i].Shout
 		}
+		snake.Shout = truncateShout(shout)
 	}
 
-	// Check game over conditions
 	gs.checkGameOver()
 }
 
+// growBy grows snake's length and body by n segments, copying its current
+// tail - the shared tail end of AppleNormal/AppleGod/AppleSpeed/AppleSleep.
+func growBy(snake *Snake, n int) {
+	snake.Length += n
+	if len(snake.Body) == 0 {
+		return
+	}
+	tail := snake.Body[len(snake.Body)-1]
+	for i := 0; i < n; i++ {
+		snake.Body = append(snake.Body, tail)
+	}
+}
+
 // ApplyAppleEffect applies the effect of eating an apple
 func (gs *GameState) ApplyAppleEffect(snakeID int, appleType AppleType) {
 	snake := gs.Snakes[snakeID-1]
-	otherSnakeID := 3 - snakeID // 1 -> 2, 2 -> 1
-	otherSnake := gs.Snakes[otherSnakeID-1]
 
 	// Restore energy to 60 when eating any apple
 	snake.Energy = 60
 
 	switch appleType {
 	case AppleNormal:
-		// Standard growth
-		snake.Length++
+		growBy(snake, 1)
 		snake.Score++
-		// Add segment to tail
-		if len(snake.Body) > 0 {
-			tail := snake.Body[len(snake.Body)-1]
-			snake.Body = append(snake.Body, tail)
-		}
 
 	case AppleGod:
-		// Worth 3 points
-		snake.Length += 3
+		growBy(snake, 3)
 		snake.Score += 3
-		// Add 3 segments to tail
-		if len(snake.Body) > 0 {
-			tail := snake.Body[len(snake.Body)-1]
-			snake.Body = append(snake.Body, tail)
-			snake.Body = append(snake.Body, tail)
-			snake.Body = append(snake.Body, tail)
-		}
 
 	case AppleSpeed:
-		// 2 moves per turn for 5 turns
-		snake.Length++
+		growBy(snake, 1)
 		snake.Score++
 		snake.SpeedTurns = 5
-		// Add segment to tail
-		if len(snake.Body) > 0 {
-			tail := snake.Body[len(snake.Body)-1]
-			snake.Body = append(snake.Body, tail)
-		}
 
 	case AppleSleep:
-		// Freeze opponent for 5 turns
-		otherSnake.SleepTurns = 5
-		snake.Length++
+		growBy(snake, 1)
 		snake.Score++
-		// Add segment to tail
-		if len(snake.Body) > 0 {
-			tail := snake.Body[len(snake.Body)-1]
-			snake.Body = append(snake.Body, tail)
+		// Freeze every other snake still in the game.
+		for i, other := range gs.Snakes {
+			if i != snakeID-1 {
+				other.SleepTurns = 5
+			}
 		}
 
 	case ApplePoison:
@@ -655,8 +881,19 @@ func (gs *GameState) Clone() *GameState {
 		GridHeight: gs.GridHeight,
 		Winner:     gs.Winner,
 		GameOver:   gs.GameOver,
+		Snakes:     make([]*Snake, len(gs.Snakes)),
 	}
 
+	// Derive the clone's RNG from the parent's stream rather than sharing
+	// it, so independent simulations (e.g. MCTS playouts branching from the
+	// same node) diverge instead of racing on one *rand.Rand.
+	if gs.rng != nil {
+		clone.seed = gs.rng.Int63()
+	} else {
+		clone.seed = time.Now().UnixNano()
+	}
+	clone.rng = rand.New(rand.NewSource(clone.seed))
+
 	// Clone snakes
 	for i := range gs.Snakes {
 		clone.Snakes[i] = &Snake{
@@ -669,6 +906,7 @@ func (gs *GameState) Clone() *GameState {
 			SleepTurns:  gs.Snakes[i].SleepTurns,
 			Energy:      gs.Snakes[i].Energy,
 			DeathReason: gs.Snakes[i].DeathReason,
+			Shout:       gs.Snakes[i].Shout,
 			Body:        make([]Position, len(gs.Snakes[i].Body)),
 		}
 		copy(clone.Snakes[i].Body, gs.Snakes[i].Body)
@@ -689,6 +927,38 @@ func (gs *GameState) Clone() *GameState {
 	return clone
 }
 
+// snapshotForReplay returns a deep copy of gs's exported fields for
+// recording in a TurnRecord. Unlike Clone, it does not derive a new RNG
+// stream - Clone consumes a draw from gs.rng to seed the copy, which is
+// fine for an independent simulation branch but would perturb the live
+// match's RNG if used purely to record history, making VerifyReplay's
+// re-simulation diverge from what actually happened.
+func (gs *GameState) snapshotForReplay() *GameState {
+	data, err := json.Marshal(gs)
+	if err != nil {
+		// gs's exported fields always marshal cleanly in practice.
+		return gs.Clone()
+	}
+	var snap GameState
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return gs.Clone()
+	}
+	return &snap
+}
+
+// snakeGlyphs are the head/body symbols used for String(), one pair per
+// snake index, cycling if there are more snakes than pairs.
+var snakeGlyphs = [][2]rune{
+	{'1', 'o'},
+	{'2', 'x'},
+	{'3', '*'},
+	{'4', '+'},
+	{'5', '~'},
+	{'6', '%'},
+	{'7', '&'},
+	{'8', '@'},
+}
+
 // String returns a visual representation of the game state
 func (gs *GameState) String() string {
 	grid := make([][]rune, gs.GridHeight)
@@ -728,27 +998,18 @@ func (gs *GameState) String() string {
 		}
 	}
 
-	// Draw Snake 1
-	if gs.Snakes[0].Alive {
-		for i, segment := range gs.Snakes[0].Body {
-			if segment.Y >= 0 && segment.Y < gs.GridHeight && segment.X >= 0 && segment.X < gs.GridWidth {
-				if i == 0 {
-					grid[segment.Y][segment.X] = '1' // Head
-				} else {
-					grid[segment.Y][segment.X] = 'o'
-				}
-			}
+	// Draw each snake
+	for i, snake := range gs.Snakes {
+		if !snake.Alive {
+			continue
 		}
-	}
-
-	// Draw Snake 2
-	if gs.Snakes[1].Alive {
-		for i, segment := range gs.Snakes[1].Body {
+		glyphs := snakeGlyphs[i%len(snakeGlyphs)]
+		for j, segment := range snake.Body {
 			if segment.Y >= 0 && segment.Y < gs.GridHeight && segment.X >= 0 && segment.X < gs.GridWidth {
-				if i == 0 {
-					grid[segment.Y][segment.X] = '2' // Head
+				if j == 0 {
+					grid[segment.Y][segment.X] = glyphs[0]
 				} else {
-					grid[segment.Y][segment.X] = 'x'
+					grid[segment.Y][segment.X] = glyphs[1]
 				}
 			}
 		}
@@ -758,8 +1019,10 @@ func (gs *GameState) String() string {
 	for _, row := range grid {
 		result += string(row) + "\n"
 	}
-	result += fmt.Sprintf("Snake 1: Alive=%v, Length=%d, Score=%d, Speed=%d, Sleep=%d, Energy=%d\n", gs.Snakes[0].Alive, gs.Snakes[0].Length, gs.Snakes[0].Score, gs.Snakes[0].SpeedTurns, gs.Snakes[0].SleepTurns, gs.Snakes[0].Energy)
-	result += fmt.Sprintf("Snake 2: Alive=%v, Length=%d, Score=%d, Speed=%d, Sleep=%d, Energy=%d\n", gs.Snakes[1].Alive, gs.Snakes[1].Length, gs.Snakes[1].Score, gs.Snakes[1].SpeedTurns, gs.Snakes[1].SleepTurns, gs.Snakes[1].Energy)
+	for _, snake := range gs.Snakes {
+		result += fmt.Sprintf("Snake %d: Alive=%v, Length=%d, Score=%d, Speed=%d, Sleep=%d, Energy=%d\n",
+			snake.ID, snake.Alive, snake.Length, snake.Score, snake.SpeedTurns, snake.SleepTurns, snake.Energy)
+	}
 
 	return result
 }
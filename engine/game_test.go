@@ -0,0 +1,159 @@
+package engine
+
+import "testing"
+
+// newTestSnake builds a snake with the given body (head first) for direct
+// placement into a GameState under test, bypassing NewGameStateForBots'
+// perimeter spawn layout.
+func newTestSnake(id int, body []Position, alive bool) *Snake {
+	return newTestSnakeFacing(id, body, DirectionRight, alive)
+}
+
+// newTestSnakeFacing is newTestSnake but lets the caller set the snake's
+// current Direction explicitly, needed whenever a test then moves the snake
+// in a direction that would otherwise look like an illegal 180-degree turn
+// against the zero-value default.
+func newTestSnakeFacing(id int, body []Position, dir Direction, alive bool) *Snake {
+	return &Snake{
+		ID:        id,
+		Body:      body,
+		Direction: dir,
+		Alive:     alive,
+		Length:    len(body),
+		Energy:    60,
+	}
+}
+
+func TestCheckCollisionWall(t *testing.T) {
+	cases := []struct {
+		name string
+		head Position
+		neck Position
+		want bool
+	}{
+		{"inside board", Position{X: 5, Y: 5}, Position{X: 5, Y: 6}, false},
+		{"negative x", Position{X: -1, Y: 5}, Position{X: 0, Y: 5}, true},
+		{"negative y", Position{X: 5, Y: -1}, Position{X: 5, Y: 0}, true},
+		{"x at width", Position{X: 11, Y: 5}, Position{X: 10, Y: 5}, true},
+		{"y at height", Position{X: 5, Y: 11}, Position{X: 5, Y: 10}, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := NewGameStateForBots(11, 11, nil, 1, 1)
+			gs.Snakes[0] = newTestSnake(1, []Position{tc.head, tc.neck}, true)
+
+			got := gs.CheckCollision(1)
+			if got != tc.want {
+				t.Errorf("CheckCollision() = %v, want %v", got, tc.want)
+			}
+			if got && gs.Snakes[0].Alive {
+				t.Errorf("collided snake should be marked dead")
+			}
+			if got && gs.Snakes[0].DeathReason != "wall" {
+				t.Errorf("DeathReason = %q, want %q", gs.Snakes[0].DeathReason, "wall")
+			}
+		})
+	}
+}
+
+func TestCheckCollisionSelf(t *testing.T) {
+	gs := NewGameStateForBots(11, 11, nil, 1, 1)
+	// Head doubles back onto its own neck.
+	gs.Snakes[0] = newTestSnake(1, []Position{
+		{X: 5, Y: 5},
+		{X: 6, Y: 5},
+		{X: 6, Y: 6},
+		{X: 5, Y: 6},
+		{X: 5, Y: 5},
+	}, true)
+
+	if !gs.CheckCollision(1) {
+		t.Fatal("expected self-collision to be detected")
+	}
+	if gs.Snakes[0].DeathReason != "self" {
+		t.Errorf("DeathReason = %q, want %q", gs.Snakes[0].DeathReason, "self")
+	}
+}
+
+func TestCheckCollisionOtherBody(t *testing.T) {
+	gs := NewGameStateForBots(11, 11, nil, 1, 2)
+	gs.Snakes[0] = newTestSnake(1, []Position{{X: 5, Y: 5}, {X: 5, Y: 6}}, true)
+	gs.Snakes[1] = newTestSnake(2, []Position{{X: 5, Y: 5}, {X: 4, Y: 5}, {X: 3, Y: 5}}, true)
+
+	if gs.CheckCollision(1) {
+		t.Fatal("snake 1's head should not collide with snake 2's head position")
+	}
+
+	// Snake 1's head runs into snake 2's non-head body segment instead.
+	gs.Snakes[0] = newTestSnake(1, []Position{{X: 4, Y: 5}, {X: 4, Y: 6}}, true)
+	if !gs.CheckCollision(1) {
+		t.Fatal("expected collision with snake 2's body")
+	}
+	if gs.Snakes[0].DeathReason != "body" {
+		t.Errorf("DeathReason = %q, want %q", gs.Snakes[0].DeathReason, "body")
+	}
+}
+
+func TestCheckCollisionDeadSnakeSkipped(t *testing.T) {
+	gs := NewGameStateForBots(11, 11, nil, 1, 1)
+	gs.Snakes[0] = newTestSnake(1, []Position{{X: -1, Y: -1}}, false)
+
+	if gs.CheckCollision(1) {
+		t.Fatal("a dead snake should never report a new collision")
+	}
+}
+
+// TestProcessTurnNHeadToHead exercises the multi-way head-to-head rule: all
+// alive snakes whose heads land on the same cell after a step collapse into
+// one group, and only the longest survives.
+func TestProcessTurnNHeadToHead(t *testing.T) {
+	t.Run("longer snake survives a two-way head-to-head", func(t *testing.T) {
+		gs := NewGameStateForBots(11, 11, nil, 1, 2)
+		// Snake 1 (length 2) moves right into (6,5); snake 2 (length 4) moves
+		// left into the same cell.
+		gs.Snakes[0] = newTestSnakeFacing(1, []Position{{X: 5, Y: 5}, {X: 4, Y: 5}}, DirectionRight, true)
+		gs.Snakes[1] = newTestSnakeFacing(2, []Position{{X: 7, Y: 5}, {X: 8, Y: 5}, {X: 9, Y: 5}, {X: 10, Y: 5}}, DirectionLeft, true)
+
+		gs.ProcessTurnN([]MoveDecision{
+			{Direction: DirectionRight},
+			{Direction: DirectionLeft},
+		})
+
+		if gs.Snakes[0].Alive {
+			t.Error("shorter snake should have died in the head-to-head")
+		}
+		if gs.Snakes[0].DeathReason != "head-to-head" {
+			t.Errorf("DeathReason = %q, want %q", gs.Snakes[0].DeathReason, "head-to-head")
+		}
+		if !gs.Snakes[1].Alive {
+			t.Error("longer snake should have survived the head-to-head")
+		}
+	})
+
+	t.Run("three-way head-to-head leaves only the longest alive", func(t *testing.T) {
+		gs := NewGameStateForBots(11, 11, nil, 1, 3)
+		gs.Snakes[0] = newTestSnake(1, []Position{{X: 5, Y: 4}, {X: 5, Y: 3}}, true)
+		gs.Snakes[1] = newTestSnake(2, []Position{{X: 5, Y: 6}, {X: 5, Y: 7}, {X: 5, Y: 8}}, true)
+		gs.Snakes[2] = newTestSnake(3, []Position{{X: 4, Y: 5}, {X: 3, Y: 5}, {X: 2, Y: 5}, {X: 1, Y: 5}}, true)
+
+		gs.ProcessTurnN([]MoveDecision{
+			{Direction: DirectionDown},
+			{Direction: DirectionUp},
+			{Direction: DirectionRight},
+		})
+
+		aliveCount := 0
+		for _, s := range gs.Snakes {
+			if s.Alive {
+				aliveCount++
+			}
+		}
+		if aliveCount != 1 {
+			t.Fatalf("expected exactly one survivor, got %d", aliveCount)
+		}
+		if !gs.Snakes[2].Alive {
+			t.Error("longest snake (id 3) should be the sole survivor")
+		}
+	})
+}
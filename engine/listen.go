@@ -0,0 +1,112 @@
+package engine
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Listener accepts bots dialing into the judge over websocket, the reverse
+// of BotConfig's "websocket" transport (where the judge dials the bot).
+// This suits hosted bot services that register themselves with a judge
+// rather than exposing a public endpoint for the judge to call.
+type Listener struct {
+	addr     string
+	upgrader websocket.Upgrader
+	server   *http.Server
+
+	mu      sync.Mutex
+	waiting map[int]chan *BotPlayer
+}
+
+// NewListener creates a Listener that will serve on addr (e.g. ":9000")
+// once Start is called.
+func NewListener(addr string) *Listener {
+	return &Listener{
+		addr:    addr,
+		waiting: make(map[int]chan *BotPlayer),
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+// Start begins serving registrations in the background. Bots connect to
+// ws://<addr>/register?id=N, where N is the bot ID a concurrent WaitForBot
+// call is waiting on.
+func (l *Listener) Start() error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/register", l.handleRegister)
+	l.server = &http.Server{Addr: l.addr, Handler: mux}
+
+	go func() {
+		if err := l.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			fmt.Printf("[Listener] server error: %v\n", err)
+		}
+	}()
+
+	return nil
+}
+
+// Close shuts down the listener's HTTP server.
+func (l *Listener) Close() error {
+	if l.server == nil {
+		return nil
+	}
+	return l.server.Close()
+}
+
+func (l *Listener) handleRegister(w http.ResponseWriter, r *http.Request) {
+	idParam := r.URL.Query().Get("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		http.Error(w, "query param \"id\" must be an integer bot ID", http.StatusBadRequest)
+		return
+	}
+
+	conn, err := l.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		fmt.Printf("[Listener] upgrade failed for bot %d: %v\n", id, err)
+		return
+	}
+
+	bot := NewWebSocketBotPlayer(id, conn)
+
+	l.mu.Lock()
+	waiter, ok := l.waiting[id]
+	l.mu.Unlock()
+
+	if !ok {
+		// Nobody is waiting for this ID (yet, or anymore) - close the
+		// connection rather than leaking it.
+		conn.Close()
+		return
+	}
+	waiter <- bot
+}
+
+// WaitForBot blocks until a bot registers under id, or timeout elapses.
+func (l *Listener) WaitForBot(id int, timeout time.Duration) (*BotPlayer, error) {
+	waiter := make(chan *BotPlayer, 1)
+
+	l.mu.Lock()
+	l.waiting[id] = waiter
+	l.mu.Unlock()
+
+	defer func() {
+		l.mu.Lock()
+		delete(l.waiting, id)
+		l.mu.Unlock()
+	}()
+
+	select {
+	case bot := <-waiter:
+		return bot, nil
+	case <-time.After(timeout):
+		return nil, fmt.Errorf("timed out waiting for bot %d to register", id)
+	}
+}
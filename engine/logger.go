@@ -0,0 +1,117 @@
+package engine
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// botLogMaxSizeMB/botLogMaxBackups/botLogMaxAgeDays bound a bot's rotating
+// log file, so a long tournament doesn't grow one unbounded text file per
+// bot the way the old single stderr log did.
+const (
+	botLogMaxSizeMB  = 10
+	botLogMaxBackups = 5
+	botLogMaxAgeDays = 7
+)
+
+// LogFields is the structured context BotPlayer attaches to its log
+// entries. Zero-valued fields are omitted.
+type LogFields struct {
+	BotID     int
+	BotName   string
+	GameID    string
+	Stream    string // "stdout" or "stderr"
+	Line      string
+	LatencyMS int64
+	Timeout   bool
+	Err       error
+}
+
+func (f LogFields) fields() logrus.Fields {
+	fields := logrus.Fields{}
+	if f.BotID != 0 {
+		fields["bot_id"] = f.BotID
+	}
+	if f.BotName != "" {
+		fields["bot_name"] = f.BotName
+	}
+	if f.GameID != "" {
+		fields["game_id"] = f.GameID
+	}
+	if f.Stream != "" {
+		fields["stream"] = f.Stream
+	}
+	if f.Line != "" {
+		fields["line"] = f.Line
+	}
+	if f.LatencyMS != 0 {
+		fields["latency_ms"] = f.LatencyMS
+	}
+	if f.Timeout {
+		fields["timeout"] = f.Timeout
+	}
+	if f.Err != nil {
+		fields["error"] = f.Err.Error()
+	}
+	return fields
+}
+
+// Logger is how a BotPlayer reports what it's doing - lifecycle events,
+// per-turn latency, and raw stdout/stderr lines - as structured entries
+// instead of ad hoc fmt.Printf calls, so a tournament dashboard or
+// post-match analysis script can consume it without grepping text.
+type Logger interface {
+	Info(fields LogFields, msg string)
+	Debug(fields LogFields, msg string)
+	Error(fields LogFields, msg string)
+}
+
+// logrusLogger is the default Logger, writing JSON entries to stderr and
+// mirroring them to a size-rotated file under the bot's directory.
+type logrusLogger struct {
+	log *logrus.Logger
+}
+
+// newLogrusLogger builds a Logger for bot botID in match gameID, rotating
+// its log file under directory via lumberjack once it exceeds
+// botLogMaxSizeMB. gameID namespaces the filename so two concurrent
+// matches sharing a bot directory (e.g. a tournament's -workers > 1,
+// where the same bot plays position 1 in several matchups at once) don't
+// point two independent lumberjack.Logger instances at the same file.
+func newLogrusLogger(directory string, botID int, gameID string) Logger {
+	if directory == "" {
+		directory = "."
+	}
+	if gameID == "" {
+		gameID = "0"
+	}
+
+	log := logrus.New()
+	log.SetFormatter(&logrus.JSONFormatter{})
+	log.SetLevel(logrus.DebugLevel)
+	log.SetOutput(io.MultiWriter(os.Stderr, &lumberjack.Logger{
+		Filename:   filepath.Join(directory, fmt.Sprintf("bot_%d_%s.log", botID, gameID)),
+		MaxSize:    botLogMaxSizeMB,
+		MaxBackups: botLogMaxBackups,
+		MaxAge:     botLogMaxAgeDays,
+	}))
+
+	return &logrusLogger{log: log}
+}
+
+func (l *logrusLogger) Info(fields LogFields, msg string) {
+	l.log.WithFields(fields.fields()).Info(msg)
+}
+
+func (l *logrusLogger) Debug(fields LogFields, msg string) {
+	l.log.WithFields(fields.fields()).Debug(msg)
+}
+
+func (l *logrusLogger) Error(fields LogFields, msg string) {
+	l.log.WithFields(fields.fields()).Error(msg)
+}
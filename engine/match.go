@@ -5,16 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
 
-type calibrateResult struct {
-	Seconds float64 `json:"seconds"`
-}
-
 // MatchConfig contains configuration for a match
 type MatchConfig struct {
 	GridWidth   int           `json:"grid_width"`
@@ -22,44 +18,55 @@ type MatchConfig struct {
 	MaxTurns    int           `json:"max_turns"`
 	TurnTimeout time.Duration `json:"turn_timeout"`
 	// Optional scaling factor applied to TurnTimeout (e.g., from calibration)
-	TurnTimeoutScale float64 `json:"turn_timeout_scale"`
-	Bot1Dir          string  `json:"bot1_directory"`
-	Bot2Dir          string  `json:"bot2_directory"`
-	ReplayOutput     string  `json:"replay_output"`
-	Verbose          bool    `json:"verbose"`
-	MapPath          string  `json:"map_path"`
+	TurnTimeoutScale float64  `json:"turn_timeout_scale"`
+	BotDirs          []string `json:"bot_directories"`
+	ReplayOutput     string   `json:"replay_output"`
+	Verbose          bool     `json:"verbose"`
+	MapPath          string   `json:"map_path"`
+	// Seed feeds the game state's RNG (apple spawning). If zero, NewMatch
+	// picks one from the current time and writes it back here so the
+	// actual seed used is recorded in MatchReplay.Config for later
+	// verification via VerifyReplay.
+	Seed int64 `json:"seed"`
+	// GameID identifies this match in bot log entries and dashboards. If
+	// empty, NewMatch derives one from Seed and writes it back here.
+	GameID string `json:"game_id"`
+	// Limits, if set, bounds every bot's CPU/memory use uniformly across
+	// the match, on top of each turn's TurnTimeout.
+	Limits ResourceLimits `json:"limits"`
+	// CalibrationBackend selects which Calibrator's reference speed scales
+	// TurnTimeout (see CalibrateTurnTimeoutScale): "python", "node", or
+	// "go". Empty auto-detects from the match's bots' configured commands.
+	CalibrationBackend string `json:"calibration_backend"`
 }
 
-// TurnRecord records what happened in a single turn
+// TurnRecord records what happened in a single turn. Moves/Timeouts/TimeTaken
+// are indexed the same way as GameState.Snakes and MatchConfig.BotDirs.
 type TurnRecord struct {
-	Turn       int           `json:"turn"`
-	GameState  *GameState    `json:"game_state"`
-	Move1      Direction     `json:"move1"`
-	Move2      Direction     `json:"move2"`
-	Timeout1   bool          `json:"timeout1"`
-	Timeout2   bool          `json:"timeout2"`
-	TimeTaken1 time.Duration `json:"time_taken1"`
-	TimeTaken2 time.Duration `json:"time_taken2"`
+	Turn         int             `json:"turn"`
+	GameState    *GameState      `json:"game_state"`
+	Moves        []Direction     `json:"moves"`
+	Timeouts     []bool          `json:"timeouts"`
+	TimeTaken    []time.Duration `json:"time_taken"`
+	CPUTimeTaken []time.Duration `json:"cpu_time_taken"`
 }
 
 // MatchReplay contains the complete history of a match
 type MatchReplay struct {
-	Config      MatchConfig            `json:"config"`
-	Turns       []TurnRecord           `json:"turns"`
-	Winner      int                    `json:"winner"`
-	WinReason   string                 `json:"win_reason"`
-	TotalTurns  int                    `json:"total_turns"`
-	Bot1Stats   map[string]interface{} `json:"bot1_stats"`
-	Bot2Stats   map[string]interface{} `json:"bot2_stats"`
-	CompletedAt time.Time              `json:"completed_at"`
+	Config      MatchConfig              `json:"config"`
+	Turns       []TurnRecord             `json:"turns"`
+	Winner      int                      `json:"winner"`
+	WinReason   string                   `json:"win_reason"`
+	TotalTurns  int                      `json:"total_turns"`
+	BotStats    []map[string]interface{} `json:"bot_stats"`
+	CompletedAt time.Time                `json:"completed_at"`
 }
 
-// Match represents a complete game match between two bots
+// Match represents a complete game match between an arbitrary number of bots
 type Match struct {
 	Config     MatchConfig
 	GameState  *GameState
-	Bot1       *BotPlayer
-	Bot2       *BotPlayer
+	Bots       []*BotPlayer
 	Replay     *MatchReplay
 	ctx        context.Context
 	cancelFunc context.CancelFunc
@@ -67,15 +74,34 @@ type Match struct {
 
 // NewMatch creates a new match with the given configuration
 func NewMatch(config MatchConfig) (*Match, error) {
+	if len(config.BotDirs) < 2 {
+		return nil, fmt.Errorf("match requires at least 2 bots, got %d", len(config.BotDirs))
+	}
+
 	// Create bot players
-	bot1, err := NewBotPlayer(1, config.Bot1Dir)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create bot 1: %w", err)
+	bots := make([]*BotPlayer, len(config.BotDirs))
+	for i, dir := range config.BotDirs {
+		bot, err := NewBotPlayer(i+1, dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create bot %d: %w", i+1, err)
+		}
+		bots[i] = bot
 	}
 
-	bot2, err := NewBotPlayer(2, config.Bot2Dir)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create bot 2: %w", err)
+	return NewMatchWithBots(config, bots)
+}
+
+// NewMatchWithBots builds a match from already-constructed bots instead of
+// bot directories, for callers that attach bots some other way than
+// NewBotPlayer - e.g. a Listener accepting websocket connections from bots
+// that dial into the judge. len(bots) must be at least 2.
+func NewMatchWithBots(config MatchConfig, bots []*BotPlayer) (*Match, error) {
+	if len(bots) < 2 {
+		return nil, fmt.Errorf("match requires at least 2 bots, got %d", len(bots))
+	}
+
+	for _, bot := range bots {
+		bot.Limits = config.Limits
 	}
 
 	// Load map file if provided
@@ -90,7 +116,7 @@ func NewMatch(config MatchConfig) (*Match, error) {
 			return nil, fmt.Errorf("failed to parse map file: %w", err)
 		}
 		mapData = &m
-		
+
 		// If map contains dimensions, use them (override config dimensions)
 		if m.Width > 0 && m.Height > 0 {
 			config.GridWidth = m.Width
@@ -98,8 +124,26 @@ func NewMatch(config MatchConfig) (*Match, error) {
 		}
 	}
 
-	// Create game state (pass loaded map if any)
-	gameState := NewGameState(config.GridWidth, config.GridHeight, mapData)
+	// Create game state (pass loaded map if any), sized for the bot count.
+	// A zero Seed means "pick one and remember it" so the replay records
+	// the seed that actually produced this match.
+	if config.Seed == 0 {
+		config.Seed = time.Now().UnixNano()
+	}
+	if config.GameID == "" {
+		config.GameID = fmt.Sprintf("%x", config.Seed)
+	}
+	for _, bot := range bots {
+		bot.GameID = config.GameID
+		bot.BoardWidth = config.GridWidth
+		bot.BoardHeight = config.GridHeight
+		// Rebuild the logger now that GameID is known, so its log file is
+		// namespaced per match instead of colliding across concurrent
+		// matches that share a bot directory (e.g. a tournament worker
+		// pool replaying the same bot in several matchups at once).
+		bot.Logger = newLogrusLogger(bot.Directory, bot.ID, bot.GameID)
+	}
+	gameState := NewGameStateForBots(config.GridWidth, config.GridHeight, mapData, config.Seed, len(bots))
 
 	// Create context for the match
 	// This parent context controls match-level cancellation. Child contexts with timeouts
@@ -109,8 +153,7 @@ func NewMatch(config MatchConfig) (*Match, error) {
 	match := &Match{
 		Config:     config,
 		GameState:  gameState,
-		Bot1:       bot1,
-		Bot2:       bot2,
+		Bots:       bots,
 		ctx:        ctx,
 		cancelFunc: cancel,
 		Replay: &MatchReplay{
@@ -146,16 +189,18 @@ func NewMatch(config MatchConfig) (*Match, error) {
 
 // Run executes the complete match
 func (m *Match) Run() error {
-	// Start both bot processes
-	if err := m.Bot1.Start(); err != nil {
-		return fmt.Errorf("failed to start bot 1: %w", err)
-	}
-	defer m.Bot1.Stop()
-
-	if err := m.Bot2.Start(); err != nil {
-		return fmt.Errorf("failed to start bot 2: %w", err)
+	// Start every bot process. If one fails partway through - including a
+	// v1 handshake timeout - stop every bot that already started instead of
+	// leaking its process/container.
+	for i, bot := range m.Bots {
+		if err := bot.Start(); err != nil {
+			for _, started := range m.Bots[:i+1] {
+				started.Stop(context.Background())
+			}
+			return fmt.Errorf("failed to start bot %d: %w", i+1, err)
+		}
 	}
-	defer m.Bot2.Stop()
+	defer m.Stop(context.Background())
 
 	// Give bots a moment to initialize
 	time.Sleep(100 * time.Millisecond)
@@ -166,7 +211,7 @@ func (m *Match) Run() error {
 	}
 
 	// Main game loop
-	for m.GameState.Turn < m.Config.MaxTurns{
+	for m.GameState.Turn < m.Config.MaxTurns {
 		if err := m.PlayTurn(); err != nil {
 			return fmt.Errorf("error on turn %d: %w", m.GameState.Turn, err)
 		}
@@ -187,14 +232,12 @@ func (m *Match) Run() error {
 
 	// Record the final state after the last turn
 	finalTurnRecord := TurnRecord{
-		Turn:       m.GameState.Turn,
-		GameState:  m.GameState.Clone(),
-		Move1:      "",
-		Move2:      "",
-		Timeout1:   false,
-		Timeout2:   false,
-		TimeTaken1: 0,
-		TimeTaken2: 0,
+		Turn:         m.GameState.Turn,
+		GameState:    m.GameState.snapshotForReplay(),
+		Moves:        make([]Direction, len(m.Bots)),
+		Timeouts:     make([]bool, len(m.Bots)),
+		TimeTaken:    make([]time.Duration, len(m.Bots)),
+		CPUTimeTaken: make([]time.Duration, len(m.Bots)),
 	}
 	m.Replay.Turns = append(m.Replay.Turns, finalTurnRecord)
 
@@ -211,86 +254,77 @@ func (m *Match) Run() error {
 
 // PlayTurn executes one turn of the game
 func (m *Match) PlayTurn() error {
-	// Record state before moves
-	stateBeforeMove := m.GameState.Clone()
+	// Record state before moves. snapshotForReplay (not Clone) so recording
+	// history doesn't perturb the live game state's RNG stream - otherwise
+	// VerifyReplay's re-simulation would diverge from what actually happened.
+	stateBeforeMove := m.GameState.snapshotForReplay()
 
-	// Use channels to get moves concurrently
 	type moveResult struct {
-		botID    int
+		botIdx   int
 		response MoveResponse
 	}
 
-	moveChan := make(chan moveResult, 2)
+	moveChan := make(chan moveResult, len(m.Bots))
 	var wg sync.WaitGroup
 
-	// Query both bots simultaneously
-	wg.Add(2)
-
-	// Bot 1
-	go func() {
-		defer wg.Done()
-		response := m.Bot1.GetMove(m.ctx, m.GameState, m.Config.TurnTimeout)
-		moveChan <- moveResult{botID: 1, response: response}
-	}()
-
-	// Bot 2
-	go func() {
-		defer wg.Done()
-		response := m.Bot2.GetMove(m.ctx, m.GameState, m.Config.TurnTimeout)
-		moveChan <- moveResult{botID: 2, response: response}
-	}()
+	// Query every bot simultaneously
+	wg.Add(len(m.Bots))
+	for i, bot := range m.Bots {
+		go func(idx int, bp *BotPlayer) {
+			defer wg.Done()
+			response := bp.GetMove(m.ctx, m.GameState, m.Config.TurnTimeout)
+			moveChan <- moveResult{botIdx: idx, response: response}
+		}(i, bot)
+	}
 
-	// Wait for both responses
 	wg.Wait()
 	close(moveChan)
 
 	// Collect responses
-	var move1Response, move2Response MoveResponse
+	responses := make([]MoveResponse, len(m.Bots))
 	for result := range moveChan {
-		if result.botID == 1 {
-			move1Response = result.response
-		} else {
-			move2Response = result.response
-		}
+		responses[result.botIdx] = result.response
 	}
 
 	// Check if bots are still alive
-	if !m.Bot1.IsAlive() && m.GameState.Snakes[0].Alive {
-		m.GameState.Snakes[0].Alive = false
-		if m.Config.Verbose {
-			fmt.Println("Bot 1 process died!")
+	for i, bot := range m.Bots {
+		if !bot.IsAlive() && m.GameState.Snakes[i].Alive {
+			m.GameState.Snakes[i].Alive = false
+			if m.Config.Verbose {
+				fmt.Printf("Bot %d process died!\n", i+1)
+			}
 		}
 	}
 
-	if !m.Bot2.IsAlive() && m.GameState.Snakes[1].Alive {
-		m.GameState.Snakes[1].Alive = false
-		if m.Config.Verbose {
-			fmt.Println("Bot 2 process died!")
-		}
+	// Process the turn with every bot's move
+	moves := make([]MoveDecision, len(m.Bots))
+	for i, response := range responses {
+		moves[i] = MoveDecision{Direction: response.Move}
 	}
-
-	// Process the turn with both moves
-	m.GameState.ProcessTurn(move1Response.Move, move2Response.Move)
+	m.GameState.ProcessTurnN(moves)
 
 	// Record the turn
 	turnRecord := TurnRecord{
-		Turn:       m.GameState.Turn,
-		GameState:  stateBeforeMove,
-		Move1:      move1Response.Move,
-		Move2:      move2Response.Move,
-		Timeout1:   move1Response.Timeout,
-		Timeout2:   move2Response.Timeout,
-		TimeTaken1: move1Response.TimeTaken,
-		TimeTaken2: move2Response.TimeTaken,
+		Turn:         m.GameState.Turn,
+		GameState:    stateBeforeMove,
+		Moves:        make([]Direction, len(m.Bots)),
+		Timeouts:     make([]bool, len(m.Bots)),
+		TimeTaken:    make([]time.Duration, len(m.Bots)),
+		CPUTimeTaken: make([]time.Duration, len(m.Bots)),
+	}
+	for i, response := range responses {
+		turnRecord.Moves[i] = response.Move
+		turnRecord.Timeouts[i] = response.Timeout
+		turnRecord.TimeTaken[i] = response.TimeTaken
+		turnRecord.CPUTimeTaken[i] = response.CPUTime
 	}
 	m.Replay.Turns = append(m.Replay.Turns, turnRecord)
 
-	if m.Config.Verbose && (move1Response.Timeout || move2Response.Timeout) {
-		if move1Response.Timeout {
-			fmt.Printf("Bot 1 timeout! (Total: %d)\n", m.Bot1.timeoutCount)
-		}
-		if move2Response.Timeout {
-			fmt.Printf("Bot 2 timeout! (Total: %d)\n", m.Bot2.timeoutCount)
+	if m.Config.Verbose {
+		for i, response := range responses {
+			if response.Timeout {
+				fmt.Printf("Bot %d timeout! (Total: %d)\n", i+1, m.Bots[i].timeoutCount)
+			}
 		}
 	}
 
@@ -302,50 +336,69 @@ func (m *Match) finalizeMatch() {
 	m.Replay.TotalTurns = m.GameState.Turn
 	m.Replay.Winner = m.GameState.Winner
 	m.Replay.CompletedAt = time.Now()
-	m.Replay.Bot1Stats = m.Bot1.GetStats()
-	m.Replay.Bot2Stats = m.Bot2.GetStats()
+
+	m.Replay.BotStats = make([]map[string]interface{}, len(m.Bots))
+	for i, bot := range m.Bots {
+		m.Replay.BotStats[i] = bot.GetStats()
+	}
 
 	// Determine win reason with specific death causes
 	if m.GameState.Winner == 0 {
 		if m.GameState.Turn >= m.Config.MaxTurns {
 			m.Replay.WinReason = "Draw - Max turns reached"
-			// Award win to longer snake
-			if m.GameState.Snakes[0].Length > m.GameState.Snakes[1].Length {
-				m.Replay.Winner = 1
-				m.Replay.WinReason = "Bot 1 wins - Longer snake at max turns"
-			} else if m.GameState.Snakes[1].Length > m.GameState.Snakes[0].Length {
-				m.Replay.Winner = 2
-				m.Replay.WinReason = "Bot 2 wins - Longer snake at max turns"
+			// Award win to the longest surviving snake, if there's a unique one
+			longest := -1
+			tie := false
+			for i, snake := range m.GameState.Snakes {
+				if !snake.Alive {
+					continue
+				}
+				if longest == -1 || snake.Length > m.GameState.Snakes[longest].Length {
+					longest = i
+					tie = false
+				} else if snake.Length == m.GameState.Snakes[longest].Length {
+					tie = true
+				}
+			}
+			if longest >= 0 && !tie {
+				m.Replay.Winner = m.GameState.Snakes[longest].ID
+				m.Replay.WinReason = fmt.Sprintf("Bot %d wins - Longer snake at max turns", m.GameState.Snakes[longest].ID)
 			}
 		} else {
-			// Both died - describe how
-			reason1 := m.getDeathDescription(m.GameState.Snakes[0].DeathReason)
-			reason2 := m.getDeathDescription(m.GameState.Snakes[1].DeathReason)
-			m.Replay.WinReason = fmt.Sprintf("Draw - Both snakes died (Bot 1: %s, Bot 2: %s)", reason1, reason2)
+			// Everyone died - describe how
+			descriptions := make([]string, len(m.GameState.Snakes))
+			for i, snake := range m.GameState.Snakes {
+				descriptions[i] = fmt.Sprintf("Bot %d: %s", snake.ID, m.getDeathDescription(snake.DeathReason))
+			}
+			m.Replay.WinReason = fmt.Sprintf("Draw - All snakes died (%s)", strings.Join(descriptions, ", "))
 		}
-	} else if m.GameState.Winner == 1 {
-		if !m.GameState.Snakes[1].Alive {
-			reason := m.getDeathDescription(m.GameState.Snakes[1].DeathReason)
-			m.Replay.WinReason = fmt.Sprintf("Bot 1 wins - Bot 2 died due to %s", reason)
-		} else {
-			m.Replay.WinReason = "Bot 1 wins"
+	} else {
+		winnerSnake := m.GameState.Snakes[m.GameState.Winner-1]
+		m.Replay.WinReason = fmt.Sprintf("Bot %d wins", winnerSnake.ID)
+	}
+
+	// Let v1-protocol bots know how the match ended before Stop closes
+	// their stdin, so they can log or train on the result.
+	for _, bot := range m.Bots {
+		result := map[string]interface{}{
+			"winner":     m.Replay.Winner == bot.ID,
+			"win_reason": m.Replay.WinReason,
+			"turns":      m.Replay.TotalTurns,
 		}
-	} else if m.GameState.Winner == 2 {
-		if !m.GameState.Snakes[0].Alive {
-			reason := m.getDeathDescription(m.GameState.Snakes[0].DeathReason)
-			m.Replay.WinReason = fmt.Sprintf("Bot 2 wins - Bot 1 died due to %s", reason)
-		} else {
-			m.Replay.WinReason = "Bot 2 wins"
+		if err := bot.SendEnd(result); err != nil {
+			fields := bot.logFields()
+			fields.Err = err
+			bot.Logger.Error(fields, "failed to send end message")
 		}
 	}
 
 	fmt.Printf("\n=== Match Complete ===\n")
 	fmt.Printf("Winner: %s\n", m.Replay.WinReason)
 	fmt.Printf("Total Turns: %d\n", m.Replay.TotalTurns)
-	fmt.Printf("Bot 1 - Timeouts: %d, Errors: %d, Final Length: %d\n",
-		m.Bot1.timeoutCount, m.Bot1.errorCount, m.GameState.Snakes[0].Length)
-	fmt.Printf("Bot 2 - Timeouts: %d, Errors: %d, Final Length: %d\n",
-		m.Bot2.timeoutCount, m.Bot2.errorCount, m.GameState.Snakes[1].Length)
+	for i, snake := range m.GameState.Snakes {
+		fmt.Printf("Bot %d - Timeouts: %d, Errors: %d, Final Length: %d\n",
+			i+1, m.Bots[i].timeoutCount, m.Bots[i].errorCount, snake.Length)
+	}
 }
 
 // getDeathDescription converts a death reason code to a human-readable description
@@ -394,85 +447,41 @@ func (m *Match) SaveReplay() error {
 	return nil
 }
 
-// Stop gracefully stops the match
-func (m *Match) Stop() {
+// Stop gracefully stops the match, giving every bot up to its
+// ShutdownGrace to exit on its own before being killed. ctx bounds the
+// whole teardown; canceling it (or letting it expire) skips straight to
+// SIGKILL for any bot still running.
+func (m *Match) Stop(ctx context.Context) {
 	if m.cancelFunc != nil {
 		m.cancelFunc()
 	}
-	if m.Bot1 != nil {
-		m.Bot1.Stop()
-	}
-	if m.Bot2 != nil {
-		m.Bot2.Stop()
+	for _, bot := range m.Bots {
+		bot.Stop(ctx)
 	}
 }
 
-// runAutomaticCalibration runs the Python calibrator and updates the
-// tools/reference_calibrate.json file. If a repository reference exists it will
-// compute a scale factor = measured_seconds / ref_seconds and, when
-// TURN_TIMEOUT_SCALE is not already set, export the value and apply it to the
-// match's TurnTimeout in NewMatch.
+// runAutomaticCalibration scales this match's TurnTimeout by
+// CalibrateTurnTimeoutScale for its CalibrationBackend (auto-detected from
+// its bots' commands if unset), unless TURN_TIMEOUT_SCALE is already set
+// in the environment as an explicit override.
 func (m *Match) runAutomaticCalibration() error {
-	// Try common python executables
-	cmds := [][]string{{"python", "tools/calibrate.py"}, {"python3", "tools/calibrate.py"}, {"py", "tools/calibrate.py"}}
-	var out []byte
-	var err error
-	for _, c := range cmds {
-		cmd := exec.Command(c[0], c[1:]...)
-		cmd.Dir = ""
-		out, err = cmd.Output()
-		if err == nil {
-			break
-		}
-	}
-	if err != nil {
-		return fmt.Errorf("failed to run calibrator: %w", err)
+	if os.Getenv("TURN_TIMEOUT_SCALE") != "" {
+		return nil
 	}
 
-	var res calibrateResult
-	if err := json.Unmarshal(out, &res); err != nil {
-		return fmt.Errorf("failed to parse calibrator output: %w", err)
+	backend := m.Config.CalibrationBackend
+	if backend == "" {
+		backend = detectCalibrationBackend(m.Bots)
 	}
 
-	// Read existing reference if present
-	refPath := "tools/reference_calibrate.json"
-	var refSeconds float64
-	if data, err := os.ReadFile(refPath); err == nil {
-		var parsed struct {
-			RefSeconds float64 `json:"ref_seconds"`
-		}
-		if err := json.Unmarshal(data, &parsed); err == nil && parsed.RefSeconds > 0 {
-			refSeconds = parsed.RefSeconds
-		}
-	}
-
-	// If no reference exists, write measured as the new reference
-	if refSeconds == 0 {
-		outData, _ := json.MarshalIndent(map[string]float64{"ref_seconds": res.Seconds}, "", "  ")
-		if err := os.WriteFile(refPath, outData, 0644); err != nil {
-			return fmt.Errorf("failed to write reference calibrate file: %w", err)
-		}
-		if m.Config.Verbose {
-			fmt.Printf("Wrote new reference calibrate value: %f (to %s)\n", res.Seconds, refPath)
-		}
-		refSeconds = res.Seconds
-	}
-
-	// Compute scale relative to the reference: measured_seconds / ref_seconds
-	scale := 1.0
-	if refSeconds > 0 {
-		scale = res.Seconds / refSeconds
+	scale, err := CalibrateTurnTimeoutScale(backend)
+	if err != nil {
+		return err
 	}
 
-	// If TURN_TIMEOUT_SCALE is not set in environment, set it so bot children
-	// inherit it when started. Also leave it for NewMatch to apply to timeouts.
-	if os.Getenv("TURN_TIMEOUT_SCALE") == "" {
-		if err := os.Setenv("TURN_TIMEOUT_SCALE", strconv.FormatFloat(scale, 'f', 6, 64)); err != nil {
-			return fmt.Errorf("failed to set TURN_TIMEOUT_SCALE env: %w", err)
-		}
-		if m.Config.Verbose {
-			fmt.Printf("Calibration: measured=%f ref=%f scale=%f (TURN_TIMEOUT_SCALE set)\n", res.Seconds, refSeconds, scale)
-		}
+	m.Config.TurnTimeoutScale = scale
+	if m.Config.Verbose {
+		fmt.Printf("Calibration: backend=%s scale=%f\n", backend, scale)
 	}
 
 	return nil
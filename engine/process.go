@@ -6,26 +6,82 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
+
+	"github.com/docker/docker/client"
+	"github.com/gorilla/websocket"
 )
 
+// defaultShutdownGrace is how long Stop waits after SIGTERM before
+// escalating to SIGKILL when a bot's config.json doesn't set ShutdownGrace.
+const defaultShutdownGrace = 2 * time.Second
+
 // BotConfig represents the configuration file for a bot
 type BotConfig struct {
 	Command []string `json:"command"` // e.g., ["python3", "bot.py"] or ["./java_bot"]
 	Name    string   `json:"name"`    // Optional display name
 	// Optional Docker image to run the bot inside. If set, the judge will
-	// execute `docker run` with the image and communicate over stdin/stdout.
+	// create and attach to a container for the image via the Docker Engine
+	// API and communicate over its attached stdin/stdout.
 	DockerImage string `json:"docker_image"`
 	// Optional Docker CPU quota (e.g. 0.5) and memory (e.g. "256m")
 	DockerCPUs   float64 `json:"docker_cpus"`
 	DockerMemory string  `json:"docker_memory"`
+
+	// Network allows the container to reach the network. Defaults to false:
+	// competitive bots have no business making outbound connections, and a
+	// closed network also stops a bot from exfiltrating or coordinating
+	// with other running bots.
+	Network bool `json:"network"`
+	// ReadOnly makes the container's root filesystem read-only other than
+	// its tmpfs mounts, so a bot can't tamper with the read-only /bot mount
+	// or leave state behind for a rematch. Defaults to true; set false
+	// explicitly to allow a bot to write to its own rootfs.
+	ReadOnly *bool `json:"read_only"`
+	// PidsLimit caps the number of processes/threads the container may
+	// create. Zero uses dockerPidsLimit's default.
+	PidsLimit int64 `json:"pids_limit"`
+	// Tmpfs lists paths to mount as an empty, writable tmpfs, for bots that
+	// need scratch space despite ReadOnly.
+	Tmpfs []string `json:"tmpfs"`
+	// Ulimits maps a ulimit name (e.g. "nofile") to a "soft:hard" pair.
+	Ulimits map[string]string `json:"ulimits"`
+	// Capabilities lists Linux capabilities to add back on top of the
+	// CapDrop ALL baseline (e.g. ["NET_BIND_SERVICE"]). Most bots need none.
+	Capabilities []string `json:"capabilities"`
+	// Seccomp optionally points at a seccomp profile file restricting the
+	// container's available syscalls beyond Docker's default profile.
+	Seccomp string `json:"seccomp"`
+
+	// ShutdownGrace is how long Stop waits after SIGTERM before escalating
+	// to SIGKILL. Defaults to defaultShutdownGrace.
+	ShutdownGrace time.Duration `json:"shutdown_grace"`
+
+	// Transport selects how the judge talks to the bot: "" or "stdio"
+	// (default) spawns Command and speaks JSON over stdin/stdout. "websocket"
+	// instead dials URL once and keeps the connection open across the whole
+	// match (and across matches, if the bot process reuses it), for bots
+	// hosted as long-lived services rather than forked per game.
+	Transport string `json:"transport"`
+	URL       string `json:"url"`
+
+	// Protocol selects the per-turn message format: "" or "legacy" (default)
+	// sends each turn's bare GameState JSON and accepts a bare move token or
+	// {"move":...}. "v1" wraps the same pipe in an init/ready handshake at
+	// Start, a {"type":"turn",...} envelope per turn, and a {"type":"end",...}
+	// notice before stdin closes - see ProtocolV1.
+	Protocol string `json:"protocol"`
 }
 
-// BotPlayer manages a single bot process
+// BotPlayer manages a single bot, over a subprocess (stdio), a Docker
+// container, or a persistent websocket connection.
 type BotPlayer struct {
 	ID           int
 	Directory    string
@@ -35,9 +91,52 @@ type BotPlayer struct {
 	stdout       io.ReadCloser
 	stderr       io.ReadCloser
 	scanner      *bufio.Scanner
+	ws           *websocket.Conn
 	isRunning    bool
 	timeoutCount int
 	errorCount   int
+
+	// Limits bounds this bot's CPU/memory use, enforced via sb once the
+	// process is started. Set by NewMatchWithBots from MatchConfig.Limits.
+	Limits ResourceLimits
+	sb     sandbox
+
+	// dockerClient and containerID are set instead of cmd when
+	// Config.DockerImage is set, and drive Stop's container teardown.
+	dockerClient *client.Client
+	containerID  string
+
+	// CPUPercent and MemBytes report live resource use for Docker bots,
+	// populated by consumeDockerStats. Zero for non-Docker bots.
+	CPUPercent float64
+	MemBytes   uint64
+	statsMu    sync.Mutex
+
+	// Logger receives structured lifecycle/stdio/move entries for this bot.
+	// Set by NewBotPlayer/NewWebSocketBotPlayer to a logrus-backed default.
+	Logger Logger
+	// GameID tags this bot's log entries with the match they belong to.
+	// Set by NewMatchWithBots from MatchConfig.GameID.
+	GameID string
+
+	// BoardWidth and BoardHeight are set by NewMatchWithBots so a
+	// Protocol == ProtocolV1 bot's init message can declare the board size
+	// up front instead of waiting for the first turn's GameState.
+	BoardWidth  int
+	BoardHeight int
+	// ready holds the metadata a Protocol == ProtocolV1 bot reported in its
+	// handshake response, or nil if it hasn't handshaken (or isn't v1).
+	ready *readyResponse
+
+	// broadcaster fans this bot's stderr lines out to every Subscribe-r,
+	// alongside the Logger's rotating file sink.
+	broadcaster *stderrBroadcaster
+}
+
+// logFields returns the LogFields common to every log entry this bot
+// emits; callers add whatever's specific to the event on top.
+func (bp *BotPlayer) logFields() LogFields {
+	return LogFields{BotID: bp.ID, BotName: bp.Config.Name, GameID: bp.GameID}
 }
 
 // MoveResponse represents a bot's response
@@ -46,6 +145,10 @@ type MoveResponse struct {
 	Timeout   bool          `json:"timeout"`
 	Error     error         `json:"error,omitempty"`
 	TimeTaken time.Duration `json:"time_taken"`
+	// CPUTime is how much CPU time the bot's sandbox measured it using to
+	// produce this move, as opposed to TimeTaken's wall-clock latency.
+	// Zero for bots with no sandbox (e.g. the websocket transport).
+	CPUTime time.Duration `json:"cpu_time"`
 }
 
 // LoadBotConfig reads the config.json from a bot directory
@@ -63,13 +166,32 @@ func LoadBotConfig(directory string) (*BotConfig, error) {
 		return nil, fmt.Errorf("failed to decode config.json: %w", err)
 	}
 
-	if len(config.Command) == 0 {
+	if config.Transport == "websocket" {
+		if config.URL == "" {
+			return nil, fmt.Errorf("config.json sets transport \"websocket\" but has no url")
+		}
+	} else if len(config.Command) == 0 {
 		return nil, fmt.Errorf("command array is empty in config.json")
 	}
 
 	return &config, nil
 }
 
+// NewWebSocketBotPlayer wraps an already-established websocket connection
+// (e.g. accepted by a Listener from a bot that dialed in) as a BotPlayer,
+// for judge configurations where bots connect to the judge rather than the
+// other way around.
+func NewWebSocketBotPlayer(id int, conn *websocket.Conn) *BotPlayer {
+	return &BotPlayer{
+		ID:          id,
+		Config:      BotConfig{Transport: "websocket"},
+		ws:          conn,
+		isRunning:   true,
+		Logger:      newLogrusLogger("", id, ""),
+		broadcaster: newStderrBroadcaster(),
+	}
+}
+
 // NewBotPlayer creates a new bot player
 func NewBotPlayer(id int, directory string) (*BotPlayer, error) {
 	config, err := LoadBotConfig(directory)
@@ -78,54 +200,56 @@ func NewBotPlayer(id int, directory string) (*BotPlayer, error) {
 	}
 
 	return &BotPlayer{
-		ID:        id,
-		Directory: directory,
-		Config:    *config,
-		isRunning: false,
+		ID:          id,
+		Directory:   directory,
+		Config:      *config,
+		isRunning:   false,
+		Logger:      newLogrusLogger(directory, id, ""),
+		broadcaster: newStderrBroadcaster(),
 	}, nil
 }
 
-// Start launches the bot process
+// Start launches the bot process, or dials its websocket URL if configured
+// for the websocket transport. Bots attached via NewWebSocketBotPlayer are
+// already connected and running, so Start is a no-op for them.
 func (bp *BotPlayer) Start() error {
 	if bp.isRunning {
+		if bp.ws != nil {
+			// Already connected via NewWebSocketBotPlayer.
+			return nil
+		}
 		return fmt.Errorf("bot %d is already running", bp.ID)
 	}
 
-	// Prepare command
-	var cmdName string
-	var cmdArgs []string
+	if bp.Config.Transport == "websocket" {
+		return bp.startWebSocket()
+	}
 
-	// If a Docker image is specified in the bot config, run the bot inside Docker.
-	// Use -i so stdin/stdout can be attached to the docker process.
 	if bp.Config.DockerImage != "" {
-		fmt.Printf("[Bot %d] Running in Docker: %s (CPUs: %.1f, Memory: %s)\n", bp.ID, bp.Config.DockerImage, bp.Config.DockerCPUs, bp.Config.DockerMemory)
-		cmdName = "docker"
-		// Build docker args: run --rm -i [--cpus X] [--memory Y] image
-		cmdArgs = []string{"run", "--rm", "-i"}
-		if bp.Config.DockerCPUs > 0 {
-			cmdArgs = append(cmdArgs, "--cpus", fmt.Sprintf("%g", bp.Config.DockerCPUs))
+		if err := bp.startDocker(); err != nil {
+			return err
 		}
-		if bp.Config.DockerMemory != "" {
-			cmdArgs = append(cmdArgs, "--memory", bp.Config.DockerMemory)
-		}
-		// Mount the bot directory into /bot inside the container so scripts/tools are accessible
-		// Only do this if a directory exists
-		if bp.Directory != "" {
-			absDir, err := filepath.Abs(bp.Directory)
-			if err == nil {
-				// mount as read-only to avoid accidental modification
-				cmdArgs = append(cmdArgs, "-v", fmt.Sprintf("%s:/bot:ro", absDir))
+		bp.isRunning = true
+		go bp.logStderr()
+		if bp.Config.Protocol == ProtocolV1 {
+			if err := bp.handshake(); err != nil {
+				return fmt.Errorf("bot %d handshake failed: %w", bp.ID, err)
 			}
 		}
-		cmdArgs = append(cmdArgs, bp.Config.DockerImage)
-	} else {
-		fmt.Printf("[Bot %d] Running locally: %v\n", bp.ID, bp.Config.Command)
-		cmdName = bp.Config.Command[0]
-		cmdArgs = bp.Config.Command[1:]
+		return nil
 	}
 
-	bp.cmd = exec.Command(cmdName, cmdArgs...)
+	if !AllowUnsandboxed {
+		return fmt.Errorf("bot %d has no docker_image and the engine was not started with -allow-unsandboxed; refusing to run untrusted code as a bare local process", bp.ID)
+	}
+
+	bp.Logger.Info(bp.logFields(), fmt.Sprintf("running locally: %v", bp.Config.Command))
+	bp.cmd = exec.Command(bp.Config.Command[0], bp.Config.Command[1:]...)
 	bp.cmd.Dir = bp.Directory
+	// Put the process in its own group so Stop can signal every process it
+	// spawns (e.g. a `python3 -m` wrapper's child interpreter), not just
+	// the direct child.
+	setProcessGroup(bp.cmd)
 
 	// Setup pipes
 	stdin, err := bp.cmd.StdinPipe()
@@ -158,33 +282,123 @@ func (bp *BotPlayer) Start() error {
 	// Log stderr in background
 	go bp.logStderr()
 
+	sb := newSandbox()
+	if err := sb.start(bp.cmd.Process.Pid, bp.Limits); err != nil {
+		fields := bp.logFields()
+		fields.Err = err
+		bp.Logger.Error(fields, "resource sandbox unavailable")
+	} else {
+		bp.sb = sb
+		if bp.Limits.WallClockBudget > 0 {
+			go bp.monitorWallClockBudget()
+		}
+	}
+
+	if bp.Config.Protocol == ProtocolV1 {
+		if err := bp.handshake(); err != nil {
+			return fmt.Errorf("bot %d handshake failed: %w", bp.ID, err)
+		}
+	}
+
 	return nil
 }
 
-// logStderr logs stderr output from the bot (for debugging)
-func (bp *BotPlayer) logStderr() {
-	// Create log file in bot directory
-	logPath := filepath.Join(bp.Directory, fmt.Sprintf("bot_%d_stderr.log", bp.ID))
-	logFile, err := os.Create(logPath)
-	if err != nil {
-		// If we can't create log file, silently consume stderr
-		scanner := bufio.NewScanner(bp.stderr)
-		for scanner.Scan() {
-			_ = scanner.Text()
+// monitorWallClockBudget polls the bot's cumulative CPU time and kills it
+// if it exceeds Limits.WallClockBudget, backstopping bots that dodge the
+// per-turn TurnTimeout by pinning a core or spawning extra threads.
+func (bp *BotPlayer) monitorWallClockBudget() {
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !bp.isRunning || bp.sb == nil {
+			return
 		}
-		return
+
+		usage, err := bp.sb.usage()
+		if err != nil {
+			continue
+		}
+		if usage.CPUTime > bp.Limits.WallClockBudget {
+			bp.Logger.Error(bp.logFields(), fmt.Sprintf("exceeded CPU budget (%s > %s), killing", usage.CPUTime, bp.Limits.WallClockBudget))
+			if bp.cmd != nil && bp.cmd.Process != nil {
+				if err := signalProcessGroup(bp.cmd.Process.Pid, syscall.SIGKILL); err != nil {
+					bp.cmd.Process.Kill()
+				}
+			}
+			return
+		}
+	}
+}
+
+// cpuTimeSince returns how much cumulative CPU time the bot has used since
+// before, or zero if it isn't running inside a resource sandbox.
+func (bp *BotPlayer) cpuTimeSince(before time.Duration) time.Duration {
+	if bp.sb == nil {
+		return 0
+	}
+	usage, err := bp.sb.usage()
+	if err != nil {
+		return 0
+	}
+	return usage.CPUTime - before
+}
+
+// startWebSocket dials the bot's configured URL and keeps the connection
+// open for the lifetime of the BotPlayer.
+func (bp *BotPlayer) startWebSocket() error {
+	u, err := url.Parse(bp.Config.URL)
+	if err != nil {
+		return fmt.Errorf("invalid websocket url for bot %d: %w", bp.ID, err)
+	}
+
+	bp.Logger.Info(bp.logFields(), fmt.Sprintf("connecting over websocket: %s", u.String()))
+
+	conn, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to dial bot %d websocket: %w", bp.ID, err)
 	}
-	defer logFile.Close()
 
+	bp.ws = conn
+	bp.isRunning = true
+	return nil
+}
+
+// logStderr logs stderr output from the bot (for debugging) and fans each
+// line out to any live Subscribe-rs for real-time spectating.
+func (bp *BotPlayer) logStderr() {
 	scanner := bufio.NewScanner(bp.stderr)
 	for scanner.Scan() {
 		line := scanner.Text()
-		fmt.Fprintln(logFile, line)
+
+		fields := bp.logFields()
+		fields.Stream = "stderr"
+		fields.Line = line
+		bp.Logger.Debug(fields, "bot stderr")
+
+		bp.broadcaster.publish(LogLine{
+			BotID:     bp.ID,
+			Stream:    "stderr",
+			Line:      line,
+			Timestamp: time.Now(),
+		})
 	}
 }
 
 // GetMove sends game state to bot and waits for a move with timeout
 func (bp *BotPlayer) GetMove(ctx context.Context, gameState *GameState, timeout time.Duration) MoveResponse {
+	response := bp.getMove(ctx, gameState, timeout)
+	fields := bp.logFields()
+	fields.LatencyMS = response.TimeTaken.Milliseconds()
+	fields.Timeout = response.Timeout
+	fields.Err = response.Error
+	bp.Logger.Debug(fields, "bot move")
+	return response
+}
+
+// getMove is GetMove's implementation, split out so GetMove can log every
+// return path (stdio or websocket, success, error, or timeout) in one place.
+func (bp *BotPlayer) getMove(ctx context.Context, gameState *GameState, timeout time.Duration) MoveResponse {
 	if !bp.isRunning {
 		return MoveResponse{
 			Move:    bp.getDefaultMove(gameState),
@@ -193,12 +407,6 @@ func (bp *BotPlayer) GetMove(ctx context.Context, gameState *GameState, timeout
 		}
 	}
 
-	startTime := time.Now()
-
-	// Create context with timeout
-	ctx, cancel := context.WithTimeout(ctx, timeout)
-	defer cancel()
-
 	// Send game state to bot with reordered snakes so bot's snake is at index 0
 	gameStateJSON, err := gameState.ToJSON(bp.ID)
 	if err != nil {
@@ -210,13 +418,39 @@ func (bp *BotPlayer) GetMove(ctx context.Context, gameState *GameState, timeout
 		}
 	}
 
+	if bp.ws != nil {
+		return bp.getMoveWebSocket(gameState, gameStateJSON, timeout)
+	}
+
+	var cpuBefore time.Duration
+	if bp.sb != nil {
+		if usage, err := bp.sb.usage(); err == nil {
+			cpuBefore = usage.CPUTime
+		}
+	}
+
+	startTime := time.Now()
+
+	// Create context with timeout
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	// Channel to receive the move
 	moveChan := make(chan MoveResponse, 1)
 
+	// v1 bots get the state wrapped in a turn envelope; legacy bots get the
+	// bare GameState JSON they've always gotten.
+	payload := gameStateJSON
+	if bp.Config.Protocol == ProtocolV1 {
+		if wrapped, err := json.Marshal(turnMessage{Type: "turn", Turn: gameState.Turn, State: gameStateJSON}); err == nil {
+			payload = wrapped
+		}
+	}
+
 	// Goroutine to send state and read response
 	go func() {
 		// Send game state
-		_, err := bp.stdin.Write(append(gameStateJSON, '\n'))
+		_, err := bp.stdin.Write(append(payload, '\n'))
 		if err != nil {
 			moveChan <- MoveResponse{
 				Move:  bp.getDefaultMove(gameState),
@@ -245,6 +479,7 @@ func (bp *BotPlayer) GetMove(ctx context.Context, gameState *GameState, timeout
 				Timeout:   false,
 				Error:     nil,
 				TimeTaken: time.Since(startTime),
+				CPUTime:   bp.cpuTimeSince(cpuBefore),
 			}
 		} else {
 			// Scanner error or EOF
@@ -266,10 +501,60 @@ func (bp *BotPlayer) GetMove(ctx context.Context, gameState *GameState, timeout
 			Timeout:   true,
 			Error:     fmt.Errorf("bot timeout"),
 			TimeTaken: timeout,
+			CPUTime:   bp.cpuTimeSince(cpuBefore),
 		}
 	}
 }
 
+// getMoveWebSocket sends gameStateJSON as a single framed text message over
+// bp.ws and waits for a move response, bounded by a per-turn deadline
+// derived from timeout.
+func (bp *BotPlayer) getMoveWebSocket(gameState *GameState, gameStateJSON []byte, timeout time.Duration) MoveResponse {
+	startTime := time.Now()
+	deadline := startTime.Add(timeout)
+
+	if err := bp.ws.SetWriteDeadline(deadline); err != nil {
+		bp.errorCount++
+		return MoveResponse{Move: bp.getDefaultMove(gameState), Error: fmt.Errorf("failed to set write deadline: %w", err)}
+	}
+	if err := bp.ws.WriteMessage(websocket.TextMessage, gameStateJSON); err != nil {
+		bp.errorCount++
+		return MoveResponse{Move: bp.getDefaultMove(gameState), Error: fmt.Errorf("failed to write to bot: %w", err)}
+	}
+
+	if err := bp.ws.SetReadDeadline(deadline); err != nil {
+		bp.errorCount++
+		return MoveResponse{Move: bp.getDefaultMove(gameState), Error: fmt.Errorf("failed to set read deadline: %w", err)}
+	}
+	_, message, err := bp.ws.ReadMessage()
+	if err != nil {
+		if netErr, ok := err.(interface{ Timeout() bool }); ok && netErr.Timeout() {
+			bp.timeoutCount++
+			return MoveResponse{
+				Move:      bp.getDefaultMove(gameState),
+				Timeout:   true,
+				Error:     fmt.Errorf("bot timeout"),
+				TimeTaken: timeout,
+			}
+		}
+		bp.errorCount++
+		return MoveResponse{Move: bp.getDefaultMove(gameState), Error: fmt.Errorf("failed to read from bot: %w", err)}
+	}
+
+	var response struct {
+		Move string `json:"move"`
+	}
+	if err := json.Unmarshal(message, &response); err != nil {
+		response.Move = strings.ToUpper(strings.TrimSpace(string(message)))
+	}
+
+	return MoveResponse{
+		Move:      bp.parseMove(response.Move),
+		Timeout:   false,
+		TimeTaken: time.Since(startTime),
+	}
+}
+
 // parseMove converts string move to Direction
 func (bp *BotPlayer) parseMove(moveStr string) Direction {
 	moveStr = strings.ToUpper(strings.TrimSpace(moveStr))
@@ -296,43 +581,123 @@ func (bp *BotPlayer) getDefaultMove(gameState *GameState) Direction {
 	return snake.Direction
 }
 
-// Stop terminates the bot process
-func (bp *BotPlayer) Stop() error {
+// Stop terminates the bot process, or closes its websocket connection.
+func (bp *BotPlayer) Stop(ctx context.Context) error {
 	if !bp.isRunning {
 		return nil
 	}
 
 	bp.isRunning = false
 
-	// Close stdin to signal bot to exit
+	if bp.sb != nil {
+		defer bp.sb.close()
+	}
+
+	if bp.ws != nil {
+		bp.ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+		return bp.ws.Close()
+	}
+
+	if bp.dockerClient != nil {
+		return bp.stopDocker(ctx)
+	}
+
+	return bp.stopProcess(ctx)
+}
+
+// stopProcess closes stdin to ask the bot to exit, then escalates from
+// SIGTERM to SIGKILL across the bot's whole process group if it hasn't
+// exited within ShutdownGrace (or ctx is canceled first). A well-behaved
+// bot gets a chance to flush logs and clean up; one that ignores SIGTERM,
+// or that spawned helper processes of its own (a `python3 -m` wrapper, a
+// JVM), doesn't get to outlive the match.
+func (bp *BotPlayer) stopProcess(ctx context.Context) error {
 	if bp.stdin != nil {
 		bp.stdin.Close()
 	}
 
-	// Give the process a moment to exit gracefully
-	time.Sleep(100 * time.Millisecond)
+	if bp.cmd == nil || bp.cmd.Process == nil {
+		return nil
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- bp.cmd.Wait() }()
+
+	if err := signalProcessGroup(bp.cmd.Process.Pid, syscall.SIGTERM); err != nil {
+		fields := bp.logFields()
+		fields.Err = err
+		bp.Logger.Error(fields, "failed to send SIGTERM")
+	}
+
+	grace := bp.Config.ShutdownGrace
+	if grace <= 0 {
+		grace = defaultShutdownGrace
+	}
+	timer := time.NewTimer(grace)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		return nil
+	case <-timer.C:
+	case <-ctx.Done():
+	}
 
-	// Kill if still running
-	if bp.cmd != nil && bp.cmd.Process != nil {
+	if err := signalProcessGroup(bp.cmd.Process.Pid, syscall.SIGKILL); err != nil {
 		bp.cmd.Process.Kill()
-		bp.cmd.Wait()
 	}
+	<-done
 
 	return nil
 }
 
 // GetStats returns statistics about the bot's performance
 func (bp *BotPlayer) GetStats() map[string]interface{} {
-	return map[string]interface{}{
+	stats := map[string]interface{}{
 		"id":            bp.ID,
 		"name":          bp.Config.Name,
 		"timeout_count": bp.timeoutCount,
 		"error_count":   bp.errorCount,
 	}
+
+	if bp.sb != nil {
+		if usage, err := bp.sb.usage(); err == nil {
+			stats["cpu_time_ms"] = usage.CPUTime.Milliseconds()
+			stats["max_rss_kb"] = usage.MaxRSSKB
+			stats["killed"] = usage.Killed
+			if usage.KillReason != "" {
+				stats["kill_reason"] = usage.KillReason
+			}
+		}
+	}
+
+	if bp.dockerClient != nil {
+		bp.statsMu.Lock()
+		stats["cpu_percent"] = bp.CPUPercent
+		stats["mem_bytes"] = bp.MemBytes
+		bp.statsMu.Unlock()
+	}
+
+	if bp.ready != nil {
+		stats["protocol_version"] = bp.ready.Protocol
+		stats["bot_reported_name"] = bp.ready.Name
+		stats["bot_version"] = bp.ready.Version
+		stats["bot_language"] = bp.ready.Language
+	}
+
+	if bp.broadcaster != nil {
+		stats["dropped_log_lines"] = bp.broadcaster.droppedCount()
+	}
+
+	return stats
 }
 
-// IsAlive checks if the bot process is still running
+// IsAlive checks if the bot process (or websocket connection) is still running
 func (bp *BotPlayer) IsAlive() bool {
+	if bp.ws != nil || bp.dockerClient != nil {
+		return bp.isRunning
+	}
+
 	if !bp.isRunning || bp.cmd == nil || bp.cmd.Process == nil {
 		return false
 	}
@@ -0,0 +1,29 @@
+//go:build windows
+
+package engine
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup is a no-op on Windows: there's no POSIX process group to
+// join. The bot's Job Object (resource_windows.go) already captures its
+// whole process tree once resource enforcement is active.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// signalProcessGroup has no SIGTERM equivalent on Windows, so a graceful
+// request is simply ignored; only SIGKILL does anything, and it terminates
+// just this process - the rest of its tree, if any, is cleaned up by its
+// Job Object closing.
+func signalProcessGroup(pid int, sig syscall.Signal) error {
+	if sig != syscall.SIGKILL {
+		return nil
+	}
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	return proc.Kill()
+}
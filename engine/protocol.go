@@ -0,0 +1,122 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	// ProtocolLegacy is the default BotConfig.Protocol: the judge sends a
+	// bare GameState each turn and accepts a bare move token or
+	// {"move":...}, with no handshake or end-of-game notice.
+	ProtocolLegacy = "legacy"
+	// ProtocolV1 layers a line-delimited init/ready/turn/move/end handshake
+	// on top of the same stdin/stdout pipe, so a bot can declare who it is
+	// up front and learn how its match ended, instead of being silently
+	// killed after the last turn.
+	ProtocolV1 = "v1"
+)
+
+// handshakeTimeout bounds how long Start waits for a v1 bot's "ready"
+// response before giving up on it.
+const handshakeTimeout = 5 * time.Second
+
+// initMessage is sent once, right after a v1 bot's process or container
+// starts, describing the match it's about to play.
+type initMessage struct {
+	Type  string `json:"type"`
+	BotID int    `json:"bot_id"`
+	Board struct {
+		Width  int `json:"width"`
+		Height int `json:"height"`
+	} `json:"board"`
+}
+
+// readyResponse is a v1 bot's reply to initMessage. Its fields are stored
+// on BotPlayer and surfaced through GetStats, so a tournament dashboard can
+// show what a bot reports about itself.
+type readyResponse struct {
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	Language string `json:"language"`
+	Protocol int    `json:"protocol"`
+}
+
+// turnMessage wraps a turn's already-serialized GameState for v1 bots.
+// Legacy bots receive gameStateJSON bare, with no envelope.
+type turnMessage struct {
+	Type  string          `json:"type"`
+	Turn  int             `json:"turn"`
+	State json.RawMessage `json:"state"`
+}
+
+// endMessage tells a v1 bot the match is over and how, so it can log or
+// train on the result before the judge closes its stdin.
+type endMessage struct {
+	Type   string      `json:"type"`
+	Result interface{} `json:"result"`
+}
+
+// handshake sends initMessage and waits for a readyResponse, storing what
+// the bot reports about itself on bp. Only called for Config.Protocol ==
+// ProtocolV1 bots, once their stdin/stdout/scanner are wired up.
+func (bp *BotPlayer) handshake() error {
+	init := initMessage{Type: "init", BotID: bp.ID}
+	init.Board.Width = bp.BoardWidth
+	init.Board.Height = bp.BoardHeight
+
+	payload, err := json.Marshal(init)
+	if err != nil {
+		return fmt.Errorf("failed to build init message: %w", err)
+	}
+	if _, err := bp.stdin.Write(append(payload, '\n')); err != nil {
+		return fmt.Errorf("failed to send init message: %w", err)
+	}
+
+	type scanResult struct {
+		ready readyResponse
+		err   error
+	}
+	resultChan := make(chan scanResult, 1)
+	go func() {
+		if !bp.scanner.Scan() {
+			resultChan <- scanResult{err: fmt.Errorf("no ready response: %w", bp.scanner.Err())}
+			return
+		}
+		var ready readyResponse
+		if err := json.Unmarshal(bp.scanner.Bytes(), &ready); err != nil {
+			resultChan <- scanResult{err: fmt.Errorf("invalid ready response: %w", err)}
+			return
+		}
+		resultChan <- scanResult{ready: ready}
+	}()
+
+	select {
+	case res := <-resultChan:
+		if res.err != nil {
+			return res.err
+		}
+		bp.ready = &res.ready
+		return nil
+	case <-time.After(handshakeTimeout):
+		return fmt.Errorf("bot %d: handshake timed out after %s", bp.ID, handshakeTimeout)
+	}
+}
+
+// SendEnd tells a v1 bot the match is over, carrying result (e.g. whether
+// it won and why), so it can log or train before the judge closes its
+// stdin. A no-op for legacy bots and for any bot that isn't running.
+func (bp *BotPlayer) SendEnd(result interface{}) error {
+	if bp.Config.Protocol != ProtocolV1 || !bp.isRunning || bp.stdin == nil {
+		return nil
+	}
+
+	payload, err := json.Marshal(endMessage{Type: "end", Result: result})
+	if err != nil {
+		return fmt.Errorf("failed to build end message: %w", err)
+	}
+	_, err = bp.stdin.Write(append(payload, '\n'))
+	return err
+}
@@ -0,0 +1,163 @@
+package engine
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ReplayTurn is one turn of a recorded game: the moves that were applied,
+// and the apples present on the board immediately afterward. The apple
+// snapshot isn't strictly necessary to reproduce the game (the seeded RNG
+// already does that deterministically), but it lets PlayReplay detect if
+// an engine change has altered apple-spawning behavior since the replay was
+// recorded.
+type ReplayTurn struct {
+	Move1  Direction `json:"move1"`
+	Move2  Direction `json:"move2"`
+	Apples []Apple   `json:"apples"`
+}
+
+// Replay is a minimal, deterministic turn log: enough to re-derive every
+// intermediate GameState exactly, given a seeded RNG.
+type Replay struct {
+	Seed   int64        `json:"seed"`
+	Width  int          `json:"width"`
+	Height int          `json:"height"`
+	Map    *Map         `json:"map,omitempty"`
+	Turns  []ReplayTurn `json:"turns"`
+}
+
+func cloneApples(apples []Apple) []Apple {
+	out := make([]Apple, len(apples))
+	copy(out, apples)
+	return out
+}
+
+// RecordGame drives gs by applying each [move1, move2] pair received from
+// moves until the channel closes or the game ends, returning the resulting
+// Replay. gs should have just been constructed (via NewGameStateWithSeed)
+// so its Seed() reflects the start of the game.
+func RecordGame(gs *GameState, moves <-chan [2]Direction) *Replay {
+	replay := &Replay{
+		Seed:   gs.Seed(),
+		Width:  gs.GridWidth,
+		Height: gs.GridHeight,
+		Map:    gs.Map,
+	}
+
+	for pair := range moves {
+		gs.ProcessTurn(pair[0], pair[1])
+		replay.Turns = append(replay.Turns, ReplayTurn{
+			Move1:  pair[0],
+			Move2:  pair[1],
+			Apples: cloneApples(gs.Apples),
+		})
+		if gs.GameOver {
+			break
+		}
+	}
+
+	return replay
+}
+
+// PlayReplay re-derives every intermediate GameState from r's seed and
+// moves, returning the final state. It returns an error if the apples
+// recorded for a turn no longer match what the engine produces from the
+// seed, which signals the replay was recorded against a different version
+// of the apple-spawning logic.
+func PlayReplay(r *Replay) (*GameState, error) {
+	gs := NewGameStateWithSeed(r.Width, r.Height, r.Map, r.Seed)
+
+	for i, turn := range r.Turns {
+		gs.ProcessTurn(turn.Move1, turn.Move2)
+		if !applesEqual(gs.Apples, turn.Apples) {
+			return gs, fmt.Errorf("replay: apple spawns diverged at turn %d", i+1)
+		}
+	}
+
+	return gs, nil
+}
+
+// StateAt replays r from the beginning and returns the GameState as of the
+// given turn count (0 is the initial state), letting a debugger/UI seek to
+// any point in the match without keeping every intermediate state around.
+func (r *Replay) StateAt(turn int) (*GameState, error) {
+	if turn < 0 || turn > len(r.Turns) {
+		return nil, fmt.Errorf("replay: turn %d out of range [0, %d]", turn, len(r.Turns))
+	}
+
+	gs := NewGameStateWithSeed(r.Width, r.Height, r.Map, r.Seed)
+	for i := 0; i < turn; i++ {
+		gs.ProcessTurn(r.Turns[i].Move1, r.Turns[i].Move2)
+	}
+	return gs, nil
+}
+
+func applesEqual(a, b []Apple) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	seen := make(map[Apple]int, len(a))
+	for _, apple := range a {
+		seen[apple]++
+	}
+	for _, apple := range b {
+		if seen[apple] == 0 {
+			return false
+		}
+		seen[apple]--
+	}
+	return true
+}
+
+type replayHeader struct {
+	Seed   int64 `json:"seed"`
+	Width  int   `json:"width"`
+	Height int   `json:"height"`
+	Map    *Map  `json:"map,omitempty"`
+}
+
+// WriteNDJSON serializes r as newline-delimited JSON: a header line with
+// the seed/dimensions/map, followed by one line per turn.
+func (r *Replay) WriteNDJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(replayHeader{Seed: r.Seed, Width: r.Width, Height: r.Height, Map: r.Map}); err != nil {
+		return fmt.Errorf("replay: failed to write ndjson header: %w", err)
+	}
+	for _, turn := range r.Turns {
+		if err := enc.Encode(turn); err != nil {
+			return fmt.Errorf("replay: failed to write ndjson turn: %w", err)
+		}
+	}
+	return nil
+}
+
+// LoadReplayNDJSON parses a Replay written by WriteNDJSON.
+func LoadReplayNDJSON(r io.Reader) (*Replay, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 1<<20), 1<<20)
+
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("replay: empty ndjson stream")
+	}
+	var header replayHeader
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		return nil, fmt.Errorf("replay: failed to decode ndjson header: %w", err)
+	}
+
+	replay := &Replay{Seed: header.Seed, Width: header.Width, Height: header.Height, Map: header.Map}
+	for scanner.Scan() {
+		var turn ReplayTurn
+		if err := json.Unmarshal(scanner.Bytes(), &turn); err != nil {
+			return nil, fmt.Errorf("replay: failed to decode ndjson turn: %w", err)
+		}
+		replay.Turns = append(replay.Turns, turn)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("replay: failed to scan ndjson: %w", err)
+	}
+
+	return replay, nil
+}
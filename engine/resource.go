@@ -0,0 +1,54 @@
+package engine
+
+import "time"
+
+// AllowUnsandboxed must be set (via the engine's -allow-unsandboxed flag)
+// before a bot with no DockerImage is allowed to run as a bare local
+// subprocess. Local bots otherwise have unrestricted network access and a
+// writable /bot directory, which is fine for trusted development but not
+// for a competitive arena running other people's code.
+var AllowUnsandboxed bool
+
+// ResourceLimits bounds a bot's CPU and memory use across a match, on top
+// of the per-turn wall-clock TurnTimeout. A bot that pins a core or spins
+// up extra threads can still dodge a wall-clock timeout while burning real
+// CPU, so these give the judge a cgroup-enforced (Linux) or Job-Object-
+// enforced (Windows) backstop that stays fair across hosts of different
+// speeds. Zero fields mean unlimited.
+type ResourceLimits struct {
+	// MemoryLimitMB caps the bot's resident set size. The process is
+	// killed if it's exceeded.
+	MemoryLimitMB int64 `json:"memory_limit_mb"`
+	// CPUQuota caps the fraction of a single CPU core the bot may use,
+	// e.g. 0.5 for half a core.
+	CPUQuota float64 `json:"cpu_quota"`
+	// WallClockBudget caps the bot's cumulative CPU time across the whole
+	// match, distinct from the per-turn TurnTimeout.
+	WallClockBudget time.Duration `json:"wall_clock_budget"`
+}
+
+// ResourceUsage is what a bot has actually consumed, sampled from its
+// sandbox at any point during or after the match.
+type ResourceUsage struct {
+	CPUTime    time.Duration `json:"cpu_time"`
+	MaxRSSKB   int64         `json:"max_rss_kb"`
+	Killed     bool          `json:"killed"`
+	KillReason string        `json:"kill_reason,omitempty"`
+}
+
+// sandbox enforces ResourceLimits against a running process and reports
+// its ResourceUsage. Implemented per-OS: resource_linux.go (cgroup v2),
+// resource_windows.go (Job Objects), resource_other.go (best-effort
+// accounting only, no enforcement) everywhere else.
+type sandbox interface {
+	// start begins enforcing limits against pid, which must already be
+	// running (e.g. just returned from exec.Cmd.Start).
+	start(pid int, limits ResourceLimits) error
+	// usage reports cumulative CPU time / peak RSS so far, and whether
+	// the process has been killed for exceeding a limit.
+	usage() (ResourceUsage, error)
+	// close releases any OS resources the sandbox is holding (the cgroup
+	// directory, the Job Object handle). Safe to call even if start
+	// failed or was never called.
+	close() error
+}
@@ -0,0 +1,104 @@
+//go:build linux
+
+package engine
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cgroupRoot holds one subdirectory per sandboxed bot process. It assumes
+// a cgroup v2 unified hierarchy mounted at the usual location, with the
+// judge running with permission to create cgroups under it (e.g. as root,
+// or delegated a subtree via systemd).
+const cgroupRoot = "/sys/fs/cgroup/beatmybot"
+
+// linuxSandbox enforces ResourceLimits via a cgroup v2 directory: writing
+// memory.max and cpu.max, then moving the bot's pid into it.
+type linuxSandbox struct {
+	dir string
+}
+
+func newSandbox() sandbox {
+	return &linuxSandbox{}
+}
+
+func (s *linuxSandbox) start(pid int, limits ResourceLimits) error {
+	s.dir = filepath.Join(cgroupRoot, fmt.Sprintf("bot-%d", pid))
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("cgroup: failed to create %s: %w", s.dir, err)
+	}
+
+	if limits.MemoryLimitMB > 0 {
+		max := limits.MemoryLimitMB * 1024 * 1024
+		if err := os.WriteFile(filepath.Join(s.dir, "memory.max"), []byte(strconv.FormatInt(max, 10)), 0644); err != nil {
+			return fmt.Errorf("cgroup: failed to set memory.max: %w", err)
+		}
+	}
+
+	if limits.CPUQuota > 0 {
+		// cpu.max is "<quota> <period>" in microseconds - a 0.5 quota
+		// over a 100ms period throttles the group to half a core.
+		const periodUsec = 100000
+		quotaUsec := int64(limits.CPUQuota * periodUsec)
+		line := fmt.Sprintf("%d %d", quotaUsec, periodUsec)
+		if err := os.WriteFile(filepath.Join(s.dir, "cpu.max"), []byte(line), 0644); err != nil {
+			return fmt.Errorf("cgroup: failed to set cpu.max: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(s.dir, "cgroup.procs"), []byte(strconv.Itoa(pid)), 0644); err != nil {
+		return fmt.Errorf("cgroup: failed to add pid %d: %w", pid, err)
+	}
+
+	return nil
+}
+
+func (s *linuxSandbox) usage() (ResourceUsage, error) {
+	var u ResourceUsage
+	if s.dir == "" {
+		return u, nil
+	}
+
+	if data, err := os.ReadFile(filepath.Join(s.dir, "cpu.stat")); err == nil {
+		for _, line := range strings.Split(string(data), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) == 2 && fields[0] == "usage_usec" {
+				if usec, err := strconv.ParseInt(fields[1], 10, 64); err == nil {
+					u.CPUTime = time.Duration(usec) * time.Microsecond
+				}
+			}
+		}
+	}
+
+	if data, err := os.ReadFile(filepath.Join(s.dir, "memory.peak")); err == nil {
+		if peak, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64); err == nil {
+			u.MaxRSSKB = peak / 1024
+		}
+	}
+
+	if data, err := os.ReadFile(filepath.Join(s.dir, "memory.events")); err == nil {
+		for _, line := range strings.Split(string(data), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) == 2 && fields[0] == "oom_kill" {
+				if n, err := strconv.Atoi(fields[1]); err == nil && n > 0 {
+					u.Killed = true
+					u.KillReason = "memory limit exceeded (cgroup oom kill)"
+				}
+			}
+		}
+	}
+
+	return u, nil
+}
+
+func (s *linuxSandbox) close() error {
+	if s.dir == "" {
+		return nil
+	}
+	return os.Remove(s.dir)
+}
@@ -0,0 +1,39 @@
+//go:build !linux && !windows
+
+package engine
+
+import (
+	"fmt"
+	"syscall"
+	"time"
+)
+
+// otherSandbox is a best-effort fallback for platforms with no cgroup or
+// Job Object equivalent wired up yet (e.g. BSD/Darwin): it reports CPU
+// time and peak RSS from getrusage(RUSAGE_CHILDREN), which covers all of
+// the judge's terminated and waited-for child processes rather than this
+// bot alone, and enforces no limits at all.
+type otherSandbox struct{}
+
+func newSandbox() sandbox {
+	return &otherSandbox{}
+}
+
+func (s *otherSandbox) start(pid int, limits ResourceLimits) error {
+	if limits.MemoryLimitMB > 0 || limits.CPUQuota > 0 {
+		return fmt.Errorf("resource limits are not enforced on this platform")
+	}
+	return nil
+}
+
+func (s *otherSandbox) usage() (ResourceUsage, error) {
+	var ru syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_CHILDREN, &ru); err != nil {
+		return ResourceUsage{}, fmt.Errorf("getrusage: %w", err)
+	}
+	cpu := time.Duration(ru.Utime.Sec)*time.Second + time.Duration(ru.Utime.Usec)*time.Microsecond +
+		time.Duration(ru.Stime.Sec)*time.Second + time.Duration(ru.Stime.Usec)*time.Microsecond
+	return ResourceUsage{CPUTime: cpu, MaxRSSKB: int64(ru.Maxrss)}, nil
+}
+
+func (s *otherSandbox) close() error { return nil }
@@ -0,0 +1,192 @@
+//go:build windows
+
+package engine
+
+import (
+	"fmt"
+	"runtime"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+var (
+	modkernel32                   = syscall.NewLazyDLL("kernel32.dll")
+	procCreateJobObjectW          = modkernel32.NewProc("CreateJobObjectW")
+	procAssignProcessToJobObject  = modkernel32.NewProc("AssignProcessToJobObject")
+	procSetInformationJobObject   = modkernel32.NewProc("SetInformationJobObject")
+	procQueryInformationJobObject = modkernel32.NewProc("QueryInformationJobObject")
+	procOpenProcess               = modkernel32.NewProc("OpenProcess")
+)
+
+const (
+	processAllAccess = 0x1F0FFF
+
+	jobObjectBasicAccounting = 1
+	jobObjectExtendedLimit   = 9
+	jobObjectCPURateControl  = 15
+
+	jobObjectLimitProcessMemory  = 0x00000100
+	jobObjectLimitKillOnJobClose = 0x00002000
+
+	jobObjectCPURateControlEnable  = 0x1
+	jobObjectCPURateControlHardCap = 0x4
+)
+
+type jobObjectBasicLimitInformation struct {
+	PerProcessUserTimeLimit int64
+	PerJobUserTimeLimit     int64
+	LimitFlags              uint32
+	MinimumWorkingSetSize   uintptr
+	MaximumWorkingSetSize   uintptr
+	ActiveProcessLimit      uint32
+	Affinity                uintptr
+	PriorityClass           uint32
+	SchedulingClass         uint32
+}
+
+type ioCounters struct {
+	ReadOperationCount  uint64
+	WriteOperationCount uint64
+	OtherOperationCount uint64
+	ReadTransferCount   uint64
+	WriteTransferCount  uint64
+	OtherTransferCount  uint64
+}
+
+type jobObjectExtendedLimitInformation struct {
+	BasicLimitInformation jobObjectBasicLimitInformation
+	IoInfo                ioCounters
+	ProcessMemoryLimit    uintptr
+	JobMemoryLimit        uintptr
+	PeakProcessMemoryUsed uintptr
+	PeakJobMemoryUsed     uintptr
+}
+
+type jobObjectBasicAccountingInformation struct {
+	TotalUserTime             int64
+	TotalKernelTime           int64
+	ThisPeriodTotalUserTime   int64
+	ThisPeriodTotalKernelTime int64
+	TotalPageFaultCount       uint32
+	TotalProcesses            uint32
+	ActiveProcesses           uint32
+	TotalTerminatedProcesses  uint32
+}
+
+// jobObjectCPURateControlInformation mirrors JOBOBJECT_CPU_RATE_CONTROL_INFORMATION,
+// collapsing its union (CpuRate/Weight/MinMaxRate) down to the one field we
+// ever set: CpuRate, in units of 1/10000 of total system CPU.
+type jobObjectCPURateControlInformation struct {
+	ControlFlags uint32
+	CPURate      uint32
+}
+
+// windowsSandbox enforces ResourceLimits via a Job Object, the Windows
+// equivalent of a Linux cgroup: the bot's process is assigned to the job,
+// which the kernel enforces memory/CPU limits against directly.
+type windowsSandbox struct {
+	job syscall.Handle
+}
+
+func newSandbox() sandbox {
+	return &windowsSandbox{}
+}
+
+func (s *windowsSandbox) start(pid int, limits ResourceLimits) error {
+	job, _, err := procCreateJobObjectW.Call(0, 0)
+	if job == 0 {
+		return fmt.Errorf("job object: CreateJobObjectW failed: %w", err)
+	}
+	s.job = syscall.Handle(job)
+
+	// Always set KillOnJobClose, even with no memory limit configured, so
+	// that closing the job (done by Stop via sb.close) terminates the
+	// bot's whole process tree - the Windows equivalent of a POSIX
+	// process-group SIGKILL.
+	info := jobObjectExtendedLimitInformation{
+		BasicLimitInformation: jobObjectBasicLimitInformation{
+			LimitFlags: jobObjectLimitKillOnJobClose,
+		},
+	}
+	if limits.MemoryLimitMB > 0 {
+		info.BasicLimitInformation.LimitFlags |= jobObjectLimitProcessMemory
+		info.ProcessMemoryLimit = uintptr(limits.MemoryLimitMB) * 1024 * 1024
+	}
+	ret, _, err := procSetInformationJobObject.Call(
+		uintptr(s.job), jobObjectExtendedLimit,
+		uintptr(unsafe.Pointer(&info)), unsafe.Sizeof(info),
+	)
+	if ret == 0 {
+		return fmt.Errorf("job object: failed to set job limits: %w", err)
+	}
+
+	if limits.CPUQuota > 0 {
+		cpuInfo := jobObjectCPURateControlInformation{
+			ControlFlags: jobObjectCPURateControlEnable | jobObjectCPURateControlHardCap,
+			// CpuRate is a percentage of total system CPU in units of
+			// 1/100 of a percent; scale our "fraction of one core" quota
+			// by the core count to match.
+			CPURate: uint32(limits.CPUQuota * 100 * 100 / float64(runtime.NumCPU())),
+		}
+		ret, _, err := procSetInformationJobObject.Call(
+			uintptr(s.job), jobObjectCPURateControl,
+			uintptr(unsafe.Pointer(&cpuInfo)), unsafe.Sizeof(cpuInfo),
+		)
+		if ret == 0 {
+			return fmt.Errorf("job object: failed to set cpu rate: %w", err)
+		}
+	}
+
+	proc, _, err := procOpenProcess.Call(processAllAccess, 0, uintptr(pid))
+	if proc == 0 {
+		return fmt.Errorf("job object: OpenProcess failed: %w", err)
+	}
+	defer syscall.CloseHandle(syscall.Handle(proc))
+
+	ret, _, err = procAssignProcessToJobObject.Call(uintptr(s.job), proc)
+	if ret == 0 {
+		return fmt.Errorf("job object: AssignProcessToJobObject failed: %w", err)
+	}
+
+	return nil
+}
+
+func (s *windowsSandbox) usage() (ResourceUsage, error) {
+	var u ResourceUsage
+	if s.job == 0 {
+		return u, nil
+	}
+
+	var acct jobObjectBasicAccountingInformation
+	var returned uint32
+	ret, _, err := procQueryInformationJobObject.Call(
+		uintptr(s.job), jobObjectBasicAccounting,
+		uintptr(unsafe.Pointer(&acct)), unsafe.Sizeof(acct),
+		uintptr(unsafe.Pointer(&returned)),
+	)
+	if ret == 0 {
+		return u, fmt.Errorf("job object: QueryInformationJobObject failed: %w", err)
+	}
+	// TotalUserTime/TotalKernelTime are in 100ns units, same as FILETIME.
+	u.CPUTime = time.Duration(acct.TotalUserTime+acct.TotalKernelTime) * 100 * time.Nanosecond
+
+	var ext jobObjectExtendedLimitInformation
+	ret, _, err = procQueryInformationJobObject.Call(
+		uintptr(s.job), jobObjectExtendedLimit,
+		uintptr(unsafe.Pointer(&ext)), unsafe.Sizeof(ext),
+		uintptr(unsafe.Pointer(&returned)),
+	)
+	if ret != 0 {
+		u.MaxRSSKB = int64(ext.PeakJobMemoryUsed) / 1024
+	}
+
+	return u, nil
+}
+
+func (s *windowsSandbox) close() error {
+	if s.job == 0 {
+		return nil
+	}
+	return syscall.CloseHandle(s.job)
+}
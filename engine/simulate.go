@@ -0,0 +1,11 @@
+package engine
+
+// Simulate returns the game state that results from applying move1 and move2
+// to a clone of gs, leaving gs untouched. It is the building block AI search
+// routines (minimax, MCTS, etc.) use to explore hypothetical futures without
+// disturbing the real match state.
+func Simulate(gs *GameState, move1, move2 Direction) *GameState {
+	next := gs.Clone()
+	next.ProcessTurn(move1, move2)
+	return next
+}
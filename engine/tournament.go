@@ -0,0 +1,420 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TournamentConfig configures a Tournament.
+type TournamentConfig struct {
+	// BotsDir holds one subdirectory per bot, each containing a config.json -
+	// the same layout NewBotPlayer expects for a single match.
+	BotsDir     string        `json:"bots_dir"`
+	GridWidth   int           `json:"grid_width"`
+	GridHeight  int           `json:"grid_height"`
+	MaxTurns    int           `json:"max_turns"`
+	TurnTimeout time.Duration `json:"turn_timeout"`
+	MapPath     string        `json:"map_path"`
+
+	// Workers bounds how many matches run concurrently. Defaults to 1.
+	Workers int `json:"workers"`
+	// ReplayDir holds one replay file per matchup, named "<botA>_vs_<botB>.json".
+	// Defaults to "replays/tournament".
+	ReplayDir string `json:"replay_dir"`
+	// ResultsPath is where the aggregated TournamentResults JSON is written.
+	// Defaults to "tournament_results.json".
+	ResultsPath string `json:"results_path"`
+
+	// DoubleElimination additionally seeds a double-elimination bracket from
+	// the round-robin standings once the round-robin stage completes. When
+	// false (the default), only the round robin is played.
+	DoubleElimination bool `json:"double_elimination"`
+
+	// CalibrationBackend selects which Calibrator's reference speed scales
+	// every match's TurnTimeout (see CalibrateTurnTimeoutScale). Empty
+	// auto-detects from the bots' configured commands.
+	CalibrationBackend string `json:"calibration_backend"`
+}
+
+// MatchResult is one completed matchup within a tournament.
+type MatchResult struct {
+	BotA       string `json:"bot_a"`
+	BotB       string `json:"bot_b"`
+	ReplayPath string `json:"replay_path"`
+	// Winner is 0 for a draw, 1 if BotA won, 2 if BotB won.
+	Winner    int    `json:"winner"`
+	WinReason string `json:"win_reason"`
+	Turns     int    `json:"turns"`
+	TimeoutsA int    `json:"timeouts_a"`
+	TimeoutsB int    `json:"timeouts_b"`
+}
+
+// Standing is one bot's aggregated record across every matchup it played.
+type Standing struct {
+	Bot              string  `json:"bot"`
+	Wins             int     `json:"wins"`
+	Losses           int     `json:"losses"`
+	Draws            int     `json:"draws"`
+	MatchesPlayed    int     `json:"matches_played"`
+	TotalTurns       int     `json:"total_turns"`
+	AvgSurvivalTurns float64 `json:"avg_survival_turns"`
+	Timeouts         int     `json:"timeouts"`
+	TimeoutRate      float64 `json:"timeout_rate"`
+}
+
+// TournamentResults is the aggregated output of a Tournament run, written to
+// TournamentConfig.ResultsPath.
+type TournamentResults struct {
+	Config      TournamentConfig `json:"config"`
+	Matches     []MatchResult    `json:"matches"`
+	Standings   []Standing       `json:"standings"`
+	CompletedAt time.Time        `json:"completed_at"`
+}
+
+// Tournament runs a round-robin (and optionally a double-elimination
+// bracket seeded from it) across every bot found in TournamentConfig.BotsDir.
+type Tournament struct {
+	Config TournamentConfig
+	// Bots holds the discovered bot folder names, in the order os.ReadDir
+	// returned them.
+	Bots []string
+}
+
+// NewTournament discovers every subdirectory of config.BotsDir containing a
+// config.json and prepares a Tournament across them.
+func NewTournament(config TournamentConfig) (*Tournament, error) {
+	entries, err := os.ReadDir(config.BotsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bots directory: %w", err)
+	}
+
+	var bots []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(config.BotsDir, entry.Name(), "config.json")); err == nil {
+			bots = append(bots, entry.Name())
+		}
+	}
+	if len(bots) < 2 {
+		return nil, fmt.Errorf("need at least 2 bots in %s, found %d", config.BotsDir, len(bots))
+	}
+
+	if config.Workers < 1 {
+		config.Workers = 1
+	}
+	if config.ReplayDir == "" {
+		config.ReplayDir = "replays/tournament"
+	}
+	if config.ResultsPath == "" {
+		config.ResultsPath = "tournament_results.json"
+	}
+
+	return &Tournament{Config: config, Bots: bots}, nil
+}
+
+// roundRobinPairs returns every unordered pair of bots exactly once.
+func roundRobinPairs(bots []string) [][2]string {
+	var pairs [][2]string
+	for i := 0; i < len(bots); i++ {
+		for j := i + 1; j < len(bots); j++ {
+			pairs = append(pairs, [2]string{bots[i], bots[j]})
+		}
+	}
+	return pairs
+}
+
+// replayPath returns the replay file a's vs b's match is recorded to.
+func (t *Tournament) replayPath(a, b string) string {
+	return filepath.Join(t.Config.ReplayDir, fmt.Sprintf("%s_vs_%s.json", a, b))
+}
+
+// Run plays the round robin (one goroutine per matchup, at most
+// TournamentConfig.Workers of them at a time), writes TournamentConfig.ResultsPath, and returns the aggregated
+// results. Matchups whose replay file already records a completed match are
+// skipped, so re-running Run after a crash resumes instead of replaying
+// everything.
+func (t *Tournament) Run() (*TournamentResults, error) {
+	if err := os.MkdirAll(t.Config.ReplayDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create replay directory: %w", err)
+	}
+
+	// Calibrate once up front so every match below shares the same scaled
+	// TurnTimeout instead of each one re-running the calibrator.
+	if t.Config.CalibrationBackend == "" {
+		t.Config.CalibrationBackend = detectCalibrationBackendFromDirs(t.Config.BotsDir, t.Bots)
+	}
+	if _, err := CalibrateTurnTimeoutScale(t.Config.CalibrationBackend); err != nil {
+		fmt.Printf("Tournament calibration warning: %v\n", err)
+	}
+
+	pairs := roundRobinPairs(t.Bots)
+	results := make([]MatchResult, len(pairs))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, t.Config.Workers)
+
+	for i, pair := range pairs {
+		replayPath := t.replayPath(pair[0], pair[1])
+
+		if existing, ok := loadCompletedMatchResult(replayPath, pair[0], pair[1]); ok {
+			fmt.Printf("Skipping %s vs %s - already completed (%s)\n", pair[0], pair[1], replayPath)
+			results[i] = existing
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, a, b, replayPath string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = t.runMatch(a, b, replayPath)
+		}(i, pair[0], pair[1], replayPath)
+	}
+	wg.Wait()
+
+	standings := aggregateStandings(t.Bots, results)
+
+	if t.Config.DoubleElimination {
+		seeds := make([]string, len(standings))
+		for i, s := range standings {
+			seeds[i] = s.Bot
+		}
+		results = append(results, t.runDoubleElimination(seeds)...)
+		standings = aggregateStandings(t.Bots, results)
+	}
+
+	tournamentResults := &TournamentResults{
+		Config:      t.Config,
+		Matches:     results,
+		Standings:   standings,
+		CompletedAt: time.Now(),
+	}
+
+	if err := tournamentResults.save(t.Config.ResultsPath); err != nil {
+		return nil, err
+	}
+
+	leaderboard := tournamentResults.leaderboardText()
+	fmt.Println(leaderboard)
+	leaderboardPath := strings.TrimSuffix(t.Config.ResultsPath, filepath.Ext(t.Config.ResultsPath)) + "_leaderboard.txt"
+	if err := os.WriteFile(leaderboardPath, []byte(leaderboard), 0644); err != nil {
+		fmt.Printf("Warning: failed to write leaderboard file: %v\n", err)
+	}
+
+	return tournamentResults, nil
+}
+
+// runMatch plays a over b, recording the replay to replayPath and returning
+// the derived MatchResult. Errors creating or running the match are
+// recorded as a draw with the error in WinReason rather than aborting the
+// whole tournament.
+func (t *Tournament) runMatch(a, b, replayPath string) MatchResult {
+	fmt.Printf("Starting %s vs %s\n", a, b)
+
+	config := MatchConfig{
+		GridWidth:          t.Config.GridWidth,
+		GridHeight:         t.Config.GridHeight,
+		MaxTurns:           t.Config.MaxTurns,
+		TurnTimeout:        t.Config.TurnTimeout,
+		BotDirs:            []string{filepath.Join(t.Config.BotsDir, a), filepath.Join(t.Config.BotsDir, b)},
+		ReplayOutput:       replayPath,
+		MapPath:            t.Config.MapPath,
+		CalibrationBackend: t.Config.CalibrationBackend,
+	}
+
+	match, err := NewMatch(config)
+	if err != nil {
+		return MatchResult{BotA: a, BotB: b, WinReason: fmt.Sprintf("error: %v", err)}
+	}
+	defer match.Stop(context.Background())
+
+	if err := match.Run(); err != nil {
+		return MatchResult{BotA: a, BotB: b, WinReason: fmt.Sprintf("error: %v", err)}
+	}
+
+	return matchResultFromReplay(a, b, replayPath, match.Replay)
+}
+
+// runDoubleElimination plays an elimination bracket seeded by round-robin
+// standings (best record first): each round pairs adjacent seeds, and a bot
+// is only eliminated once it has lost twice (a draw counts as neither a win
+// nor a loss and advances the higher seed). Byes auto-advance whoever is
+// left unpaired. Returns the bracket's match results, to be appended to the
+// round robin's.
+func (t *Tournament) runDoubleElimination(seeds []string) []MatchResult {
+	losses := make(map[string]int, len(seeds))
+	active := append([]string(nil), seeds...)
+	var bracketResults []MatchResult
+
+	for round := 1; len(active) > 1 && round <= len(seeds)*4; round++ {
+		var next []string
+		for i := 0; i < len(active); i += 2 {
+			if i+1 >= len(active) {
+				next = append(next, active[i]) // bye
+				continue
+			}
+
+			a, b := active[i], active[i+1]
+			replayPath := filepath.Join(t.Config.ReplayDir, fmt.Sprintf("bracket_r%d_%s_vs_%s.json", round, a, b))
+			result := t.runMatch(a, b, replayPath)
+			bracketResults = append(bracketResults, result)
+
+			winner, loser := a, b
+			if result.Winner == 2 {
+				winner, loser = b, a
+			}
+			if result.Winner != 0 {
+				losses[loser]++
+			}
+
+			next = append(next, winner)
+			if losses[loser] < 2 {
+				next = append(next, loser)
+			}
+		}
+		active = next
+	}
+
+	return bracketResults
+}
+
+// matchResultFromReplay derives a MatchResult from a completed MatchReplay.
+func matchResultFromReplay(a, b, replayPath string, replay *MatchReplay) MatchResult {
+	timeoutsA, timeoutsB := 0, 0
+	if len(replay.BotStats) > 0 {
+		timeoutsA = statInt(replay.BotStats[0], "timeout_count")
+	}
+	if len(replay.BotStats) > 1 {
+		timeoutsB = statInt(replay.BotStats[1], "timeout_count")
+	}
+
+	return MatchResult{
+		BotA:       a,
+		BotB:       b,
+		ReplayPath: replayPath,
+		Winner:     replay.Winner,
+		WinReason:  replay.WinReason,
+		Turns:      replay.TotalTurns,
+		TimeoutsA:  timeoutsA,
+		TimeoutsB:  timeoutsB,
+	}
+}
+
+func statInt(stats map[string]interface{}, key string) int {
+	v, ok := stats[key]
+	if !ok {
+		return 0
+	}
+	if f, ok := v.(float64); ok {
+		return int(f)
+	}
+	return 0
+}
+
+// loadCompletedMatchResult reads replayPath as a MatchReplay and reports
+// whether it represents an already-finished a-vs-b match (CompletedAt set),
+// so Tournament.Run can skip re-playing it after a crash.
+func loadCompletedMatchResult(replayPath, a, b string) (MatchResult, bool) {
+	data, err := os.ReadFile(replayPath)
+	if err != nil {
+		return MatchResult{}, false
+	}
+
+	var replay MatchReplay
+	if err := json.Unmarshal(data, &replay); err != nil {
+		return MatchResult{}, false
+	}
+	if replay.CompletedAt.IsZero() {
+		return MatchResult{}, false
+	}
+
+	return matchResultFromReplay(a, b, replayPath, &replay), true
+}
+
+// aggregateStandings rolls every match result up into a per-bot Standing,
+// sorted by wins (descending), then average survival turns (descending).
+func aggregateStandings(bots []string, results []MatchResult) []Standing {
+	byBot := make(map[string]*Standing, len(bots))
+	for _, bot := range bots {
+		byBot[bot] = &Standing{Bot: bot}
+	}
+
+	for _, r := range results {
+		sa, sb := byBot[r.BotA], byBot[r.BotB]
+		if sa == nil || sb == nil {
+			continue
+		}
+
+		sa.MatchesPlayed++
+		sb.MatchesPlayed++
+		sa.TotalTurns += r.Turns
+		sb.TotalTurns += r.Turns
+		sa.Timeouts += r.TimeoutsA
+		sb.Timeouts += r.TimeoutsB
+
+		switch r.Winner {
+		case 1:
+			sa.Wins++
+			sb.Losses++
+		case 2:
+			sb.Wins++
+			sa.Losses++
+		default:
+			sa.Draws++
+			sb.Draws++
+		}
+	}
+
+	standings := make([]Standing, 0, len(bots))
+	for _, bot := range bots {
+		s := *byBot[bot]
+		if s.MatchesPlayed > 0 {
+			s.AvgSurvivalTurns = float64(s.TotalTurns) / float64(s.MatchesPlayed)
+			s.TimeoutRate = float64(s.Timeouts) / float64(s.MatchesPlayed)
+		}
+		standings = append(standings, s)
+	}
+
+	sort.Slice(standings, func(i, j int) bool {
+		if standings[i].Wins != standings[j].Wins {
+			return standings[i].Wins > standings[j].Wins
+		}
+		return standings[i].AvgSurvivalTurns > standings[j].AvgSurvivalTurns
+	})
+
+	return standings
+}
+
+// save writes r as indented JSON to path.
+func (r *TournamentResults) save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode tournament results: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write tournament results: %w", err)
+	}
+	return nil
+}
+
+// leaderboardText renders a human-readable standings table.
+func (r *TournamentResults) leaderboardText() string {
+	var b strings.Builder
+	fmt.Fprintln(&b, "=== Tournament Leaderboard ===")
+	fmt.Fprintf(&b, "%-20s %5s %5s %5s %8s %10s\n", "Bot", "W", "L", "D", "Timeout%", "AvgTurns")
+	for _, s := range r.Standings {
+		fmt.Fprintf(&b, "%-20s %5d %5d %5d %7.1f%% %10.1f\n",
+			s.Bot, s.Wins, s.Losses, s.Draws, s.TimeoutRate*100, s.AvgSurvivalTurns)
+	}
+	fmt.Fprintln(&b, strings.Repeat("=", 60))
+	return b.String()
+}
@@ -1,10 +1,12 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"snakegame/engine"
@@ -12,8 +14,7 @@ import (
 
 func main() {
 	// Define command-line flags
-	bot1Dir := flag.String("bot1", "", "Bot 1 folder name in bots directory (required)")
-	bot2Dir := flag.String("bot2", "", "Bot 2 folder name in bots directory (required)")
+	botsFlag := flag.String("bots", "", "Comma-separated bot folder names in bots directory, 2 or more (required unless -listen is set)")
 	width := flag.Int("width", 20, "Grid width")
 	height := flag.Int("height", 20, "Grid height")
 	maxTurns := flag.Int("max-turns", 500, "Maximum number of turns")
@@ -21,68 +22,74 @@ func main() {
 	replayOutput := flag.String("output", "replays/match_replay.json", "Replay output file")
 	verbose := flag.Bool("verbose", false, "Enable verbose output")
 	mapPath := flag.String("map", "", "Optional map JSON file with obstacles")
+	listenAddr := flag.String("listen", "", "Instead of spawning -bots, listen on this address (e.g. :9000) for bots to dial in over websocket and register")
+	numBots := flag.Int("num-bots", 2, "Number of bots to wait for when -listen is set")
+	registerTimeout := flag.Duration("register-timeout", 30*time.Second, "How long to wait for each bot to register when -listen is set")
+	tournamentDir := flag.String("tournament", "", "Instead of a single match, run a round-robin tournament across every bot folder in this directory")
+	workers := flag.Int("workers", 1, "Number of tournament matches to run concurrently when -tournament is set")
+	replayDir := flag.String("replay-dir", "replays/tournament", "Directory to store per-matchup replays in when -tournament is set")
+	resultsPath := flag.String("results", "tournament_results.json", "Where to write aggregated results when -tournament is set")
+	doubleElim := flag.Bool("double-elimination", false, "After the round robin, also run a double-elimination bracket seeded from its standings")
+	allowUnsandboxed := flag.Bool("allow-unsandboxed", false, "Allow bots with no docker_image to run as bare local processes (unsafe for untrusted bot code)")
 
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "Snake Game Engine - A competitive Snake game for bot battles\n\n")
 		fmt.Fprintf(os.Stderr, "Usage: %s [options]\n\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "Required flags:\n")
-		fmt.Fprintf(os.Stderr, "  -bot1 string\n")
-		fmt.Fprintf(os.Stderr, "        Bot 1 folder name in bots directory (must contain config.json)\n")
-		fmt.Fprintf(os.Stderr, "  -bot2 string\n")
-		fmt.Fprintf(os.Stderr, "        Bot 2 folder name in bots directory (must contain config.json)\n\n")
+		fmt.Fprintf(os.Stderr, "  -bots string\n")
+		fmt.Fprintf(os.Stderr, "        Comma-separated bot folder names in bots directory, 2 or more (each must contain config.json)\n\n")
 		fmt.Fprintf(os.Stderr, "Optional flags:\n")
 		flag.PrintDefaults()
 		fmt.Fprintf(os.Stderr, "\nExample:\n")
-		fmt.Fprintf(os.Stderr, "  %s -bot1 player1 -bot2 player2 -verbose\n", os.Args[0])
-		fmt.Fprintf(os.Stderr, "  %s -bot1 python_bot -bot2 go_bot -width 25 -height 25 -max-turns 1000\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -bots player1,player2 -verbose\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -bots python_bot,go_bot,rust_bot -width 25 -height 25 -max-turns 1000\n\n", os.Args[0])
 	}
 
 	flag.Parse()
 
-	// Validate required arguments
-	if *bot1Dir == "" || *bot2Dir == "" {
-		fmt.Fprintf(os.Stderr, "Error: Both -bot1 and -bot2 flags are required\n\n")
-		flag.Usage()
-		os.Exit(1)
-	}
-
-	// Prepend .\bots\ to the folder names
-	bot1Path := filepath.Join(".", "bots", *bot1Dir)
-	bot2Path := filepath.Join(".", "bots", *bot2Dir)
-
-	// Convert to absolute paths
-	bot1AbsPath, err := filepath.Abs(bot1Path)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Invalid bot1 path: %v\n", err)
-		os.Exit(1)
-	}
-
-	bot2AbsPath, err := filepath.Abs(bot2Path)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Invalid bot2 path: %v\n", err)
-		os.Exit(1)
-	}
+	engine.AllowUnsandboxed = *allowUnsandboxed
 
-	// Check if directories exist
-	if _, err := os.Stat(bot1AbsPath); os.IsNotExist(err) {
-		fmt.Fprintf(os.Stderr, "Error: Bot 1 directory does not exist: %s\n", bot1AbsPath)
-		os.Exit(1)
+	if *listenAddr != "" {
+		runListenMode(*listenAddr, *numBots, *registerTimeout, *width, *height, *maxTurns, *timeout, *replayOutput, *verbose, *mapPath)
+		return
 	}
 
-	if _, err := os.Stat(bot2AbsPath); os.IsNotExist(err) {
-		fmt.Fprintf(os.Stderr, "Error: Bot 2 directory does not exist: %s\n", bot2AbsPath)
-		os.Exit(1)
+	if *tournamentDir != "" {
+		runTournamentMode(*tournamentDir, *workers, *replayDir, *resultsPath, *doubleElim, *width, *height, *maxTurns, *timeout, *mapPath)
+		return
 	}
 
-	// Check for config.json in both directories
-	if _, err := os.Stat(filepath.Join(bot1AbsPath, "config.json")); os.IsNotExist(err) {
-		fmt.Fprintf(os.Stderr, "Error: config.json not found in bot 1 directory: %s\n", bot1AbsPath)
+	// Validate required arguments
+	botDirs := splitBotDirs(*botsFlag)
+	if len(botDirs) < 2 {
+		fmt.Fprintf(os.Stderr, "Error: -bots must list 2 or more comma-separated bot folder names\n\n")
+		flag.Usage()
 		os.Exit(1)
 	}
 
-	if _, err := os.Stat(filepath.Join(bot2AbsPath, "config.json")); os.IsNotExist(err) {
-		fmt.Fprintf(os.Stderr, "Error: config.json not found in bot 2 directory: %s\n", bot2AbsPath)
-		os.Exit(1)
+	// Resolve each bot folder under .\bots\ to an absolute path and make
+	// sure it exists and has a config.json
+	botAbsPaths := make([]string, len(botDirs))
+	for i, dir := range botDirs {
+		botPath := filepath.Join(".", "bots", dir)
+
+		botAbsPath, err := filepath.Abs(botPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Invalid bot path for %q: %v\n", dir, err)
+			os.Exit(1)
+		}
+
+		if _, err := os.Stat(botAbsPath); os.IsNotExist(err) {
+			fmt.Fprintf(os.Stderr, "Error: Bot directory does not exist: %s\n", botAbsPath)
+			os.Exit(1)
+		}
+
+		if _, err := os.Stat(filepath.Join(botAbsPath, "config.json")); os.IsNotExist(err) {
+			fmt.Fprintf(os.Stderr, "Error: config.json not found in bot directory: %s\n", botAbsPath)
+			os.Exit(1)
+		}
+
+		botAbsPaths[i] = botAbsPath
 	}
 
 	// Create match configuration
@@ -91,8 +98,7 @@ func main() {
 		GridHeight:   *height,
 		MaxTurns:     *maxTurns,
 		TurnTimeout:  time.Duration(*timeout) * time.Millisecond,
-		Bot1Dir:      bot1AbsPath,
-		Bot2Dir:      bot2AbsPath,
+		BotDirs:      botAbsPaths,
 		ReplayOutput: *replayOutput,
 		Verbose:      *verbose,
 		MapPath:      *mapPath,
@@ -107,8 +113,10 @@ func main() {
 	fmt.Printf("  Max Turns:     %d\n", config.MaxTurns)
 	fmt.Printf("  Turn Timeout:  %dms\n", *timeout)
 	fmt.Printf("  Replay Output: %s\n", config.ReplayOutput)
-	fmt.Printf("\nBot 1: %s\n", bot1AbsPath)
-	fmt.Printf("Bot 2: %s\n", bot2AbsPath)
+	fmt.Println()
+	for i, path := range botAbsPaths {
+		fmt.Printf("Bot %d: %s\n", i+1, path)
+	}
 	fmt.Println()
 
 	// Create and run the match
@@ -119,7 +127,7 @@ func main() {
 	}
 
 	// Handle cleanup on exit
-	defer match.Stop()
+	defer match.Stop(context.Background())
 
 	// Run the match
 	if err := match.Run(); err != nil {
@@ -131,3 +139,110 @@ func main() {
 	fmt.Println("║           MATCH COMPLETED SUCCESSFULLY        ║")
 	fmt.Println("╚═══════════════════════════════════════════════╝")
 }
+
+// runListenMode listens on addr for numBots bots to dial in over websocket
+// and register (ws://<addr>/register?id=N for N in [1, numBots]), then runs
+// a match against them once they've all connected.
+func runListenMode(addr string, numBots int, registerTimeout time.Duration, width, height, maxTurns, timeoutMs int, replayOutput string, verbose bool, mapPath string) {
+	if numBots < 2 {
+		fmt.Fprintf(os.Stderr, "Error: -num-bots must be 2 or more\n")
+		os.Exit(1)
+	}
+
+	listener := engine.NewListener(addr)
+	if err := listener.Start(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error starting listener: %v\n", err)
+		os.Exit(1)
+	}
+	defer listener.Close()
+
+	fmt.Printf("Listening on %s for %d bots to register at /register?id=N ...\n", addr, numBots)
+
+	bots := make([]*engine.BotPlayer, numBots)
+	for i := 0; i < numBots; i++ {
+		bot, err := listener.WaitForBot(i+1, registerTimeout)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error waiting for bot %d: %v\n", i+1, err)
+			os.Exit(1)
+		}
+		bots[i] = bot
+		fmt.Printf("Bot %d registered\n", i+1)
+	}
+
+	config := engine.MatchConfig{
+		GridWidth:    width,
+		GridHeight:   height,
+		MaxTurns:     maxTurns,
+		TurnTimeout:  time.Duration(timeoutMs) * time.Millisecond,
+		ReplayOutput: replayOutput,
+		Verbose:      verbose,
+		MapPath:      mapPath,
+	}
+
+	match, err := engine.NewMatchWithBots(config, bots)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating match: %v\n", err)
+		os.Exit(1)
+	}
+	defer match.Stop(context.Background())
+
+	if err := match.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error running match: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("\n╔═══════════════════════════════════════════════╗")
+	fmt.Println("║           MATCH COMPLETED SUCCESSFULLY        ║")
+	fmt.Println("╚═══════════════════════════════════════════════╝")
+}
+
+// runTournamentMode runs a round-robin tournament across every bot folder
+// found in botsDir, optionally following it with a double-elimination
+// bracket seeded from the round-robin standings.
+func runTournamentMode(botsDir string, workers int, replayDir, resultsPath string, doubleElim bool, width, height, maxTurns, timeoutMs int, mapPath string) {
+	config := engine.TournamentConfig{
+		BotsDir:           botsDir,
+		GridWidth:         width,
+		GridHeight:        height,
+		MaxTurns:          maxTurns,
+		TurnTimeout:       time.Duration(timeoutMs) * time.Millisecond,
+		MapPath:           mapPath,
+		Workers:           workers,
+		ReplayDir:         replayDir,
+		ResultsPath:       resultsPath,
+		DoubleElimination: doubleElim,
+	}
+
+	tournament, err := engine.NewTournament(config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating tournament: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Running round-robin tournament across %d bots in %s (%d worker(s))...\n", len(tournament.Bots), botsDir, workers)
+
+	results, err := tournament.Run()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error running tournament: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("\nTournament complete. %d matches played. Results written to %s\n", len(results.Matches), resultsPath)
+	fmt.Println("\nStandings:")
+	for i, s := range results.Standings {
+		fmt.Printf("  %d. %-20s W:%d L:%d D:%d  avg survival: %.1f turns\n", i+1, s.Bot, s.Wins, s.Losses, s.Draws, s.AvgSurvivalTurns)
+	}
+}
+
+// splitBotDirs parses the comma-separated -bots flag value, dropping
+// whitespace and empty entries.
+func splitBotDirs(raw string) []string {
+	var dirs []string
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part != "" {
+			dirs = append(dirs, part)
+		}
+	}
+	return dirs
+}
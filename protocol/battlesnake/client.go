@@ -0,0 +1,94 @@
+package battlesnake
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Client talks to a single remote bot implementing the Battlesnake HTTP
+// API: GET / for info, and POST /start, /move, /end for the game
+// lifecycle.
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a Client with a sane default timeout. baseURL should
+// not have a trailing slash (e.g. "http://localhost:8000").
+func NewClient(baseURL string) *Client {
+	return &Client{
+		BaseURL:    baseURL,
+		HTTPClient: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (c *Client) postJSON(ctx context.Context, path string, body interface{}, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("battlesnake: failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("battlesnake: failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("battlesnake: request to %s failed: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("battlesnake: %s returned status %d", path, resp.StatusCode)
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// Info fetches the bot's static metadata from GET /.
+func (c *Client) Info(ctx context.Context) (InfoResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+"/", nil)
+	if err != nil {
+		return InfoResponse{}, fmt.Errorf("battlesnake: failed to build info request: %w", err)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return InfoResponse{}, fmt.Errorf("battlesnake: info request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var info InfoResponse
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return InfoResponse{}, fmt.Errorf("battlesnake: failed to decode info response: %w", err)
+	}
+	return info, nil
+}
+
+// Start notifies the bot that a new game has begun.
+func (c *Client) Start(ctx context.Context, req GameRequest) error {
+	return c.postJSON(ctx, "/start", req, nil)
+}
+
+// Move asks the bot for its move this turn.
+func (c *Client) Move(ctx context.Context, req GameRequest) (MoveResponse, error) {
+	var move MoveResponse
+	if err := c.postJSON(ctx, "/move", req, &move); err != nil {
+		return MoveResponse{}, err
+	}
+	return move, nil
+}
+
+// End notifies the bot that the game has finished.
+func (c *Client) End(ctx context.Context, req GameRequest) error {
+	return c.postJSON(ctx, "/end", req, nil)
+}
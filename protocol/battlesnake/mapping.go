@@ -0,0 +1,95 @@
+package battlesnake
+
+import (
+	"fmt"
+	"strings"
+
+	"snakegame/engine"
+)
+
+func toCoord(p engine.Position) Coord {
+	return Coord{X: p.X, Y: p.Y}
+}
+
+func toCoords(positions []engine.Position) []Coord {
+	coords := make([]Coord, len(positions))
+	for i, p := range positions {
+		coords[i] = toCoord(p)
+	}
+	return coords
+}
+
+// toBattlesnakeInfo maps one of the engine's snakes onto the public schema.
+// Health is derived from Energy, which plays the same role (0 = starved).
+func toBattlesnakeInfo(s *engine.Snake) BattlesnakeInfo {
+	return BattlesnakeInfo{
+		ID:     fmt.Sprintf("snake-%d", s.ID),
+		Name:   fmt.Sprintf("Snake %d", s.ID),
+		Health: s.Energy,
+		Body:   toCoords(s.Body),
+		Head:   toCoord(s.GetHead()),
+		Length: s.Length,
+	}
+}
+
+// toBoard maps a GameState onto a Battlesnake Board. Snakes keep
+// gs.Snakes' order; the receiving snake is identified by GameRequest.You
+// (see ToGameRequest), not by its position in Board.Snakes.
+func toBoard(gs *engine.GameState) Board {
+	board := Board{
+		Height: gs.GridHeight,
+		Width:  gs.GridWidth,
+		Food:   []Coord{},
+	}
+
+	for _, apple := range gs.Apples {
+		coord := Coord{X: apple.X, Y: apple.Y}
+		board.Food = append(board.Food, coord)
+		board.CustomApples = append(board.CustomApples, CustomApple{Coord: coord, Type: string(apple.Type)})
+	}
+
+	if gs.Map != nil {
+		board.Hazards = toCoords(gs.Map.Obstacles)
+	}
+
+	for _, snake := range gs.Snakes {
+		board.Snakes = append(board.Snakes, toBattlesnakeInfo(snake))
+	}
+
+	return board
+}
+
+// ToGameRequest builds the payload sent to forSnakeID's bot for the current
+// turn of gs.
+func ToGameRequest(gameID string, gs *engine.GameState, forSnakeID int) GameRequest {
+	return GameRequest{
+		Game: Game{
+			ID:      gameID,
+			Ruleset: Ruleset{Name: "standard", Version: "beatmybot-v1"},
+			Map:     "standard",
+			Source:  "beatmybot",
+		},
+		Turn:  gs.Turn,
+		Board: toBoard(gs),
+		You:   toBattlesnakeInfo(gs.Snakes[forSnakeID-1]),
+	}
+}
+
+// directionFromMove parses a Battlesnake /move response's "move" string
+// into an engine Direction. The Battlesnake vocabulary (up/down/left/right,
+// case-insensitive) is a strict subset of the engine's own, so this is a
+// straightforward uppercase mapping.
+func directionFromMove(move string) (engine.Direction, error) {
+	switch strings.ToLower(move) {
+	case "up":
+		return engine.DirectionUp, nil
+	case "down":
+		return engine.DirectionDown, nil
+	case "left":
+		return engine.DirectionLeft, nil
+	case "right":
+		return engine.DirectionRight, nil
+	default:
+		return "", fmt.Errorf("battlesnake: unrecognized move %q", move)
+	}
+}
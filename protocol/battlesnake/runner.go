@@ -0,0 +1,129 @@
+package battlesnake
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"snakegame/engine"
+)
+
+// Runner drives a match of this engine using two remote Battlesnake HTTP
+// bots instead of local subprocess BotPlayers, turning the engine into a
+// drop-in arena for anything written against the public spec.
+type Runner struct {
+	GameID string
+	Bot1   *Client
+	Bot2   *Client
+
+	MaxTurns    int
+	TurnTimeout time.Duration
+}
+
+// NewRunner creates a Runner for the given bot base URLs.
+func NewRunner(gameID, bot1URL, bot2URL string, maxTurns int, turnTimeout time.Duration) *Runner {
+	return &Runner{
+		GameID:      gameID,
+		Bot1:        NewClient(bot1URL),
+		Bot2:        NewClient(bot2URL),
+		MaxTurns:    maxTurns,
+		TurnTimeout: turnTimeout,
+	}
+}
+
+// Play runs gs to completion (or MaxTurns), calling ProcessTurn once per
+// turn with the moves returned by each bot's /move endpoint. It returns the
+// final state.
+func (r *Runner) Play(ctx context.Context, gs *engine.GameState) (*engine.GameState, error) {
+	if err := r.notifyStart(ctx, gs); err != nil {
+		return gs, err
+	}
+
+	for gs.Turn < r.MaxTurns && !gs.GameOver {
+		move1, move2 := r.fetchMoves(ctx, gs)
+		gs.ProcessTurn(move1, move2)
+	}
+
+	r.notifyEnd(ctx, gs)
+	return gs, nil
+}
+
+func (r *Runner) notifyStart(ctx context.Context, gs *engine.GameState) error {
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if err := r.Bot1.Start(ctx, ToGameRequest(r.GameID, gs, 1)); err != nil {
+			errs <- fmt.Errorf("bot 1: %w", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		if err := r.Bot2.Start(ctx, ToGameRequest(r.GameID, gs, 2)); err != nil {
+			errs <- fmt.Errorf("bot 2: %w", err)
+		}
+	}()
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		return err
+	}
+	return nil
+}
+
+func (r *Runner) notifyEnd(ctx context.Context, gs *engine.GameState) {
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		r.Bot1.End(ctx, ToGameRequest(r.GameID, gs, 1))
+	}()
+	go func() {
+		defer wg.Done()
+		r.Bot2.End(ctx, ToGameRequest(r.GameID, gs, 2))
+	}()
+	wg.Wait()
+}
+
+// fetchMoves queries both bots concurrently, falling back to the snake's
+// current direction if a bot errors or times out, mirroring BotPlayer's
+// behavior for the stdio transport.
+func (r *Runner) fetchMoves(ctx context.Context, gs *engine.GameState) (engine.Direction, engine.Direction) {
+	turnCtx, cancel := context.WithTimeout(ctx, r.TurnTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var move1, move2 engine.Direction
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		move1 = r.fetchMove(turnCtx, r.Bot1, gs, 1)
+	}()
+	go func() {
+		defer wg.Done()
+		move2 = r.fetchMove(turnCtx, r.Bot2, gs, 2)
+	}()
+	wg.Wait()
+
+	return move1, move2
+}
+
+func (r *Runner) fetchMove(ctx context.Context, client *Client, gs *engine.GameState, snakeID int) engine.Direction {
+	fallback := gs.Snakes[snakeID-1].Direction
+
+	resp, err := client.Move(ctx, ToGameRequest(r.GameID, gs, snakeID))
+	if err != nil {
+		return fallback
+	}
+
+	dir, err := directionFromMove(resp.Move)
+	if err != nil {
+		return fallback
+	}
+	return dir
+}
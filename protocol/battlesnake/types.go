@@ -0,0 +1,88 @@
+// Package battlesnake maps this engine's GameState/Snake/Apple/Direction
+// types onto the public Battlesnake HTTP API (https://docs.battlesnake.com),
+// so any bot written against that spec can play here unmodified.
+package battlesnake
+
+// Coord is a single grid cell, using the Battlesnake convention of (0,0) at
+// the bottom-left of the board.
+type Coord struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// Ruleset describes the game rules in effect, per the Battlesnake spec.
+type Ruleset struct {
+	Name     string                 `json:"name"`
+	Version  string                 `json:"version"`
+	Settings map[string]interface{} `json:"settings,omitempty"`
+}
+
+// Game identifies the match a request belongs to.
+type Game struct {
+	ID      string  `json:"id"`
+	Ruleset Ruleset `json:"ruleset"`
+	Map     string  `json:"map"`
+	Timeout int     `json:"timeout"`
+	Source  string  `json:"source"`
+}
+
+// CustomApple is a non-standard extension of Board carrying this engine's
+// apple types (GOD/SPEED/SLEEP/POISON), which the official spec has no
+// field for. Clients that don't understand this key can safely ignore it
+// and fall back to treating every cell in Food as a plain apple.
+type CustomApple struct {
+	Coord
+	Type string `json:"type"`
+}
+
+// Board is the public board state for one turn.
+type Board struct {
+	Height  int               `json:"height"`
+	Width   int               `json:"width"`
+	Food    []Coord           `json:"food"`
+	Hazards []Coord           `json:"hazards"`
+	Snakes  []BattlesnakeInfo `json:"snakes"`
+
+	// CustomApples is a documented, non-standard extension (see
+	// CustomApple) that carries the full apple-type information this
+	// engine needs but the stock Battlesnake schema does not model.
+	CustomApples []CustomApple `json:"beatmybot_apples,omitempty"`
+}
+
+// BattlesnakeInfo describes one snake on the board, from the Battlesnake
+// Board/Battlesnake schema.
+type BattlesnakeInfo struct {
+	ID      string  `json:"id"`
+	Name    string  `json:"name"`
+	Health  int     `json:"health"`
+	Body    []Coord `json:"body"`
+	Latency string  `json:"latency"`
+	Head    Coord   `json:"head"`
+	Length  int     `json:"length"`
+	Shout   string  `json:"shout,omitempty"`
+}
+
+// GameRequest is the payload POSTed to a bot's /start, /move and /end
+// endpoints.
+type GameRequest struct {
+	Game  Game            `json:"game"`
+	Turn  int             `json:"turn"`
+	Board Board           `json:"board"`
+	You   BattlesnakeInfo `json:"you"`
+}
+
+// MoveResponse is what a bot returns from /move.
+type MoveResponse struct {
+	Move  string `json:"move"`
+	Shout string `json:"shout,omitempty"`
+}
+
+// InfoResponse is what a bot returns from GET /.
+type InfoResponse struct {
+	APIVersion string `json:"apiversion"`
+	Author     string `json:"author,omitempty"`
+	Color      string `json:"color,omitempty"`
+	Head       string `json:"head,omitempty"`
+	Tail       string `json:"tail,omitempty"`
+	Version    string `json:"version,omitempty"`
+}